@@ -6,7 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
-	_ "github.com/pfrest/pfsense_exporter/internal/collectors"
+	"github.com/pfrest/pfsense_exporter/internal/collectors"
 	"github.com/pfrest/pfsense_exporter/internal/log"
 	"github.com/pfrest/pfsense_exporter/internal/registry"
 	"github.com/pfrest/pfsense_exporter/internal/utils"
@@ -67,17 +67,31 @@ func main() {
 	// Load the config and registry
 	utils.LoadConfig(args.Config)
 	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
-		// Get the target from the request parameters.
-		targetParam := r.URL.Query().Get("target")
-		target, err := utils.GetTarget(targetParam)
-		if err != nil {
-			http.Error(w, "Bad target", http.StatusBadRequest)
-			return
+		reg := prometheus.NewRegistry()
+
+		// Scrape all members of an HA pair if a cluster was requested, otherwise scrape a single target.
+		if clusterParam := r.URL.Query().Get("cluster"); clusterParam != "" {
+			pair, err := utils.GetHAPair(clusterParam)
+			if err != nil {
+				http.Error(w, "Bad cluster", http.StatusBadRequest)
+				return
+			}
+
+			// Setup the cluster collector for the HA pair
+			reg.MustRegister(collectors.NewCARPClusterCollector(pair))
+		} else {
+			// Get the target from the request parameters.
+			targetParam := r.URL.Query().Get("target")
+			target, err := utils.GetTarget(targetParam)
+			if err != nil {
+				http.Error(w, "Bad target", http.StatusBadRequest)
+				return
+			}
+
+			// Setup collectors for the target
+			reg.MustRegister(registry.NewMasterCollector(target))
 		}
 
-		// Create a new Prometheus registry for the target and setup collectors
-		reg := prometheus.NewRegistry()
-		reg.MustRegister(registry.NewMasterCollector(target))
 		h := promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
 		h.ServeHTTP(w, r)
 	})
@@ -0,0 +1,193 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewCaptivePortalCollector())
+}
+
+// CaptivePortalCollector collects metrics about captive portal zones, sessions and vouchers.
+type CaptivePortalCollector struct {
+	captivePortalSessionsCount    *prometheus.GaugeVec
+	captivePortalSessionsBytes    *prometheus.GaugeVec
+	captivePortalIdleTimeout      *prometheus.GaugeVec
+	captivePortalHardTimeout      *prometheus.GaugeVec
+	captivePortalVoucherRollCount *prometheus.GaugeVec
+	captivePortalVouchersLeft     *prometheus.GaugeVec
+}
+
+// CaptivePortalZoneStats represents the structure of the captive portal zone data returned by the API. The
+// timeouts are in minutes and are 0 when not set.
+type CaptivePortalZoneStats struct {
+	Zone        string  `json:"zone"`
+	Enable      bool    `json:"enable"`
+	IdleTimeout float64 `json:"idletimeout"`
+	HardTimeout float64 `json:"timeout"`
+}
+
+// CaptivePortalSessionStats represents the structure of the captive portal session data returned by the API.
+type CaptivePortalSessionStats struct {
+	Zone     string  `json:"zone"`
+	BytesIn  float64 `json:"bytes_in"`
+	BytesOut float64 `json:"bytes_out"`
+}
+
+// CaptivePortalVoucherRollStats represents the structure of the captive portal voucher roll data returned by the API.
+type CaptivePortalVoucherRollStats struct {
+	Zone   string  `json:"zone"`
+	Number int64   `json:"number"`
+	Count  float64 `json:"count"`
+	Used   float64 `json:"used"`
+}
+
+// NewCaptivePortalCollector is the constructor
+func NewCaptivePortalCollector() *CaptivePortalCollector {
+	return &CaptivePortalCollector{
+		captivePortalSessionsCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_sessions_count",
+				Help: "The number of active sessions in the captive portal zone.",
+			},
+			[]string{"host", "zone"},
+		),
+		captivePortalSessionsBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_sessions_bytes",
+				Help: "The number of bytes transferred by the active sessions in the captive portal zone by direction.",
+			},
+			[]string{"host", "zone", "direction"},
+		),
+		captivePortalIdleTimeout: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_idle_timeout_seconds",
+				Help: "The idle timeout after which sessions in the captive portal zone are disconnected, 0 if disabled.",
+			},
+			[]string{"host", "zone"},
+		),
+		captivePortalHardTimeout: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_hard_timeout_seconds",
+				Help: "The hard timeout after which sessions in the captive portal zone are disconnected, 0 if disabled.",
+			},
+			[]string{"host", "zone"},
+		),
+		captivePortalVoucherRollCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_voucher_roll_vouchers_count",
+				Help: "The number of vouchers in the captive portal voucher roll.",
+			},
+			[]string{"host", "zone", "roll"},
+		),
+		captivePortalVouchersLeft: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "captive_portal_voucher_roll_remaining_count",
+				Help: "The number of unused vouchers left in the captive portal voucher roll.",
+			},
+			[]string{"host", "zone", "roll"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *CaptivePortalCollector) Name() string {
+	return "captive_portal"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *CaptivePortalCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.captivePortalSessionsCount.Describe(ch)
+	c.captivePortalSessionsBytes.Describe(ch)
+	c.captivePortalIdleTimeout.Describe(ch)
+	c.captivePortalHardTimeout.Describe(ch)
+	c.captivePortalVoucherRollCount.Describe(ch)
+	c.captivePortalVouchersLeft.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *CaptivePortalCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the captive portal zones from the target
+	var zones []CaptivePortalZoneStats
+	if err := utils.RequestData(target, "GET", "/api/v2/services/captive_portal/zones", &zones); err != nil {
+		log.Error("captive_portal", "failed to fetch captive portal zones from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each enabled zone identified
+	enabled := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		if !zone.Enable {
+			continue
+		}
+		enabled[zone.Zone] = true
+		c.captivePortalIdleTimeout.WithLabelValues(target.Host, zone.Zone).Set(zone.IdleTimeout * 60)
+		c.captivePortalHardTimeout.WithLabelValues(target.Host, zone.Zone).Set(zone.HardTimeout * 60)
+	}
+
+	// Only query sessions and vouchers when there is at least one enabled zone
+	if len(enabled) > 0 {
+		// Extract metrics for each session in an enabled zone. Session metrics are only reported once the sessions
+		// were fetched, so a failed request isn't mistaken for zones without sessions.
+		var sessions []CaptivePortalSessionStats
+		if err := utils.RequestData(target, "GET", "/api/v2/status/captive_portal/sessions", &sessions); err != nil {
+			log.Error("captive_portal", "failed to fetch captive portal sessions from host %s: %s", target.Host, err.Error())
+		} else {
+			for zone := range enabled {
+				c.captivePortalSessionsCount.WithLabelValues(target.Host, zone).Set(0)
+				c.captivePortalSessionsBytes.WithLabelValues(target.Host, zone, "in").Set(0)
+				c.captivePortalSessionsBytes.WithLabelValues(target.Host, zone, "out").Set(0)
+			}
+			for _, session := range sessions {
+				if !enabled[session.Zone] {
+					continue
+				}
+				c.captivePortalSessionsCount.WithLabelValues(target.Host, session.Zone).Inc()
+				c.captivePortalSessionsBytes.WithLabelValues(target.Host, session.Zone, "in").Add(session.BytesIn)
+				c.captivePortalSessionsBytes.WithLabelValues(target.Host, session.Zone, "out").Add(session.BytesOut)
+			}
+		}
+
+		// Extract metrics for each voucher roll in an enabled zone
+		var rolls []CaptivePortalVoucherRollStats
+		if err := utils.RequestData(target, "GET", "/api/v2/services/captive_portal/voucher_rolls", &rolls); err != nil {
+			log.Error("captive_portal", "failed to fetch captive portal voucher rolls from host %s: %s", target.Host, err.Error())
+		} else {
+			for _, roll := range rolls {
+				if !enabled[roll.Zone] {
+					continue
+				}
+				number := fmt.Sprintf("%d", roll.Number)
+				c.captivePortalVoucherRollCount.WithLabelValues(target.Host, roll.Zone, number).Set(roll.Count)
+				c.captivePortalVouchersLeft.WithLabelValues(target.Host, roll.Zone, number).Set(max(roll.Count-roll.Used, 0))
+			}
+		}
+	}
+
+	// Collect the metrics
+	c.captivePortalSessionsCount.Collect(ch)
+	c.captivePortalSessionsBytes.Collect(ch)
+	c.captivePortalIdleTimeout.Collect(ch)
+	c.captivePortalHardTimeout.Collect(ch)
+	c.captivePortalVoucherRollCount.Collect(ch)
+	c.captivePortalVouchersLeft.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *CaptivePortalCollector) resetMetrics() {
+	c.captivePortalSessionsCount.Reset()
+	c.captivePortalSessionsBytes.Reset()
+	c.captivePortalIdleTimeout.Reset()
+	c.captivePortalHardTimeout.Reset()
+	c.captivePortalVoucherRollCount.Reset()
+	c.captivePortalVouchersLeft.Reset()
+}
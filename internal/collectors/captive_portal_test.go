@@ -0,0 +1,72 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestCaptivePortalCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/services/captive_portal/zones": `[
+			{"zone":"guest","enable":true,"idletimeout":30,"timeout":480},
+			{"zone":"lobby","enable":true,"idletimeout":0,"timeout":0},
+			{"zone":"old","enable":false,"idletimeout":10,"timeout":60}
+		]`,
+		"/api/v2/status/captive_portal/sessions": `[
+			{"zone":"guest","bytes_in":1000,"bytes_out":5000},
+			{"zone":"guest","bytes_in":500,"bytes_out":2500},
+			{"zone":"old","bytes_in":1,"bytes_out":1}
+		]`,
+		"/api/v2/services/captive_portal/voucher_rolls": `[
+			{"zone":"guest","number":1,"count":100,"used":97},
+			{"zone":"old","number":2,"count":50,"used":0}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewCaptivePortalCollector(), target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_captive_portal_sessions_count", map[string]string{"zone": "guest"}, 2},
+		{"pfsense_captive_portal_sessions_count", map[string]string{"zone": "lobby"}, 0},
+		{"pfsense_captive_portal_sessions_bytes", map[string]string{"zone": "guest", "direction": "in"}, 1500},
+		{"pfsense_captive_portal_sessions_bytes", map[string]string{"zone": "guest", "direction": "out"}, 7500},
+		{"pfsense_captive_portal_idle_timeout_seconds", map[string]string{"zone": "guest"}, 1800},
+		{"pfsense_captive_portal_hard_timeout_seconds", map[string]string{"zone": "guest"}, 28800},
+		{"pfsense_captive_portal_voucher_roll_vouchers_count", map[string]string{"zone": "guest", "roll": "1"}, 100},
+		{"pfsense_captive_portal_voucher_roll_remaining_count", map[string]string{"zone": "guest", "roll": "1"}, 3},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+
+	// Disabled zones should be skipped entirely
+	for _, sample := range samples {
+		if sample.labels["zone"] == "old" {
+			t.Errorf("Expected no metrics for the disabled zone, got %s", sample.name)
+		}
+	}
+}
+
+func TestCaptivePortalCollectorCollectWithTargetSessionsError(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/services/captive_portal/zones": `[{"zone":"guest","enable":true,"idletimeout":30,"timeout":480}]`,
+	})
+
+	samples := collectTestSamples(t, NewCaptivePortalCollector(), target)
+
+	// Session and voucher metrics should be omitted rather than reported as zero when they can't be fetched
+	for _, name := range []string{"pfsense_captive_portal_sessions_count", "pfsense_captive_portal_sessions_bytes", "pfsense_captive_portal_voucher_roll_vouchers_count"} {
+		if count := countTestSamples(samples, name); count != 0 {
+			t.Errorf("Expected no %s metrics, got %d", name, count)
+		}
+	}
+	if count := countTestSamples(samples, "pfsense_captive_portal_idle_timeout_seconds"); count != 1 {
+		t.Errorf("Expected 1 idle timeout metric, got %d", count)
+	}
+}
@@ -20,6 +20,12 @@ type CARPCollector struct {
 	carpEnabled                *prometheus.GaugeVec
 	carpMaintenanceModeEnabled *prometheus.GaugeVec
 	carpVirtualIPStatus        *prometheus.GaugeVec
+	carpVirtualIPAdvSkew       *prometheus.GaugeVec
+	carpVirtualIPAdvBase       *prometheus.GaugeVec
+	carpPFSyncEnabled          *prometheus.GaugeVec
+	carpPFSyncInfo             *prometheus.GaugeVec
+	carpConfigSyncEnabled      *prometheus.GaugeVec
+	carpConfigSyncInfo         *prometheus.GaugeVec
 }
 
 // CARPStats represents the structure of the system's CARP status data returned by the API.
@@ -35,6 +41,17 @@ type CARPVirtualIPStatus struct {
 	Subnet     string `json:"subnet"`
 	VHID       int64  `json:"vhid"`
 	Interface  string `json:"interface"`
+	AdvSkew    int64  `json:"advskew"`
+	AdvBase    int64  `json:"advbase"`
+}
+
+// HASyncStats represents the structure of the system's high availability sync settings returned by the API.
+type HASyncStats struct {
+	PFSyncEnabled   bool   `json:"pfsyncenabled"`
+	PFSyncInterface string `json:"pfsyncinterface"`
+	PFSyncPeerIP    string `json:"pfsyncpeerip"`
+	SynchronizeToIP string `json:"synchronizetoip"`
+	Username        string `json:"username"`
 }
 
 // NewCARPCollector is the constructor
@@ -61,6 +78,48 @@ func NewCARPCollector() *CARPCollector {
 			},
 			[]string{"host", "carp_status", "uniqid", "subnet", "vhid", "interface"},
 		),
+		carpVirtualIPAdvSkew: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_virtual_ip_advskew",
+				Help: "The CARP advertisement skew configured on the virtual IP (lower values are preferred as MASTER).",
+			},
+			[]string{"host", "uniqid", "vhid", "interface"},
+		),
+		carpVirtualIPAdvBase: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_virtual_ip_advbase_seconds",
+				Help: "The CARP advertisement base interval configured on the virtual IP in seconds.",
+			},
+			[]string{"host", "uniqid", "vhid", "interface"},
+		),
+		carpPFSyncEnabled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_pfsync_enabled",
+				Help: "Whether pfsync state synchronization is enabled (1 = enabled, 0 = disabled).",
+			},
+			[]string{"host"},
+		),
+		carpPFSyncInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_pfsync_info",
+				Help: "Contains details about the pfsync state synchronization interface and peer.",
+			},
+			[]string{"host", "interface", "peer_ip"},
+		),
+		carpConfigSyncEnabled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_config_sync_enabled",
+				Help: "Whether XMLRPC configuration synchronization is enabled (1 = enabled, 0 = disabled).",
+			},
+			[]string{"host"},
+		),
+		carpConfigSyncInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_config_sync_info",
+				Help: "Contains details about the XMLRPC configuration synchronization peer.",
+			},
+			[]string{"host", "sync_to_ip", "username"},
+		),
 	}
 }
 
@@ -73,6 +132,12 @@ func (c *CARPCollector) Name() string {
 func (c *CARPCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.carpEnabled.Describe(ch)
 	c.carpMaintenanceModeEnabled.Describe(ch)
+	c.carpVirtualIPAdvSkew.Describe(ch)
+	c.carpVirtualIPAdvBase.Describe(ch)
+	c.carpPFSyncEnabled.Describe(ch)
+	c.carpPFSyncInfo.Describe(ch)
+	c.carpConfigSyncEnabled.Describe(ch)
+	c.carpConfigSyncInfo.Describe(ch)
 }
 
 // Collect fetches the stats and sends them to the channel.
@@ -95,12 +160,12 @@ func (c *CARPCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *u
 		return
 	}
 
-	// Reset old metrics before collecting new data
-	c.resetMetrics()
-
-	// Update the system CARP metrics
-	c.carpEnabled.WithLabelValues(target.Host).Set(float64(utils.BoolToFloat64(stats.Enabled)))
-	c.carpMaintenanceModeEnabled.WithLabelValues(target.Host).Set(float64(utils.BoolToFloat64(stats.MaintenanceMode)))
+	// Collect the HA sync settings from the target. These are optional, so failures don't prevent the CARP metrics.
+	var hasync HASyncStats
+	hasyncErr := utils.RequestData(target, "GET", "/api/v2/system/hasync", &hasync)
+	if hasyncErr != nil {
+		log.Error("carp", "failed to fetch HA sync settings from host %s: %s", target.Host, hasyncErr.Error())
+	}
 
 	// Collect virtual IP CARP metrics from the target
 	resp, err = utils.Request(target, "GET", "/api/v2/firewall/virtual_ips?mode=carp")
@@ -120,6 +185,16 @@ func (c *CARPCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *u
 		return
 	}
 
+	// Reset old metrics before collecting new data
+	c.resetMetrics()
+
+	// Update the system CARP metrics
+	c.carpEnabled.WithLabelValues(target.Host).Set(float64(utils.BoolToFloat64(stats.Enabled)))
+	c.carpMaintenanceModeEnabled.WithLabelValues(target.Host).Set(float64(utils.BoolToFloat64(stats.MaintenanceMode)))
+	if hasyncErr == nil {
+		c.updateHASync(target, hasync)
+	}
+
 	// Extract metrics for each virtual IP identified
 	for _, ip := range virtualIPs {
 		c.carpVirtualIPStatus.WithLabelValues(
@@ -130,12 +205,36 @@ func (c *CARPCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *u
 			fmt.Sprintf("%d", ip.VHID), // Convert VHID from int64 to string
 			ip.Interface,
 		).Set(CARPStatusToFloat64(ip.CARPStatus))
+
+		vhid := fmt.Sprintf("%d", ip.VHID)
+		c.carpVirtualIPAdvSkew.WithLabelValues(target.Host, ip.UniqID, vhid, ip.Interface).Set(float64(ip.AdvSkew))
+		c.carpVirtualIPAdvBase.WithLabelValues(target.Host, ip.UniqID, vhid, ip.Interface).Set(float64(ip.AdvBase))
 	}
 
 	// Collect the metrics
 	c.carpEnabled.Collect(ch)
 	c.carpMaintenanceModeEnabled.Collect(ch)
 	c.carpVirtualIPStatus.Collect(ch)
+	c.carpVirtualIPAdvSkew.Collect(ch)
+	c.carpVirtualIPAdvBase.Collect(ch)
+	c.carpPFSyncEnabled.Collect(ch)
+	c.carpPFSyncInfo.Collect(ch)
+	c.carpConfigSyncEnabled.Collect(ch)
+	c.carpConfigSyncInfo.Collect(ch)
+}
+
+// updateHASync updates the pfsync and XMLRPC sync metrics.
+func (c *CARPCollector) updateHASync(target *utils.Target, hasync HASyncStats) {
+	c.carpPFSyncEnabled.WithLabelValues(target.Host).Set(utils.BoolToFloat64(hasync.PFSyncEnabled))
+	if hasync.PFSyncEnabled {
+		c.carpPFSyncInfo.WithLabelValues(target.Host, hasync.PFSyncInterface, hasync.PFSyncPeerIP).Set(1)
+	}
+
+	// XMLRPC sync is considered enabled whenever a peer to synchronize to is configured
+	c.carpConfigSyncEnabled.WithLabelValues(target.Host).Set(utils.BoolToFloat64(hasync.SynchronizeToIP != ""))
+	if hasync.SynchronizeToIP != "" {
+		c.carpConfigSyncInfo.WithLabelValues(target.Host, hasync.SynchronizeToIP, hasync.Username).Set(1)
+	}
 }
 
 // resetMetrics resets all metrics in the collector.
@@ -143,6 +242,12 @@ func (c *CARPCollector) resetMetrics() {
 	c.carpEnabled.Reset()
 	c.carpMaintenanceModeEnabled.Reset()
 	c.carpVirtualIPStatus.Reset()
+	c.carpVirtualIPAdvSkew.Reset()
+	c.carpVirtualIPAdvBase.Reset()
+	c.carpPFSyncEnabled.Reset()
+	c.carpPFSyncInfo.Reset()
+	c.carpConfigSyncEnabled.Reset()
+	c.carpConfigSyncInfo.Reset()
 }
 
 // CARPStatusToFloat64 converts a CARP status string to a float64 value.
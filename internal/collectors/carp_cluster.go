@@ -0,0 +1,160 @@
+package collectors
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CARPClusterCollector collects metrics about the CARP status of a high availability cluster. Unlike other
+// collectors, it is not registered with the registry since it scrapes every member target of an HA pair.
+type CARPClusterCollector struct {
+	Pair               *utils.HAPair
+	carpClusterNodeUp  *prometheus.GaugeVec
+	carpClusterMasters *prometheus.GaugeVec
+	carpClusterMaster  *prometheus.GaugeVec
+	carpClusterSkew    *prometheus.GaugeVec
+}
+
+// carpClusterNode holds the CARP virtual IP statuses scraped from a single member of a cluster.
+type carpClusterNode struct {
+	host       string
+	up         bool
+	virtualIPs []CARPVirtualIPStatus
+}
+
+// NewCARPClusterCollector is the constructor
+func NewCARPClusterCollector(pair *utils.HAPair) *CARPClusterCollector {
+	return &CARPClusterCollector{
+		Pair: pair,
+		carpClusterNodeUp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_cluster_node_up",
+				Help: "Whether the CARP status of the cluster node could be scraped (1) or not (0).",
+			},
+			[]string{"cluster", "node"},
+		),
+		carpClusterMasters: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_cluster_masters",
+				Help: "The number of cluster nodes that are MASTER for the VHID (0 = no master, 2+ = split-brain).",
+			},
+			[]string{"cluster", "vhid", "interface"},
+		),
+		carpClusterMaster: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_cluster_master",
+				Help: "Whether the cluster node is MASTER for the VHID (1) or not (0).",
+			},
+			[]string{"cluster", "vhid", "interface", "node"},
+		),
+		carpClusterSkew: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "carp_cluster_skew_inverted",
+				Help: "Whether the BACKUP cluster node advertises more frequently than the MASTER for the VHID (1) or not (0).",
+			},
+			[]string{"cluster", "vhid", "interface", "node"},
+		),
+	}
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *CARPClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.carpClusterNodeUp.Describe(ch)
+	c.carpClusterMasters.Describe(ch)
+	c.carpClusterMaster.Describe(ch)
+	c.carpClusterSkew.Describe(ch)
+}
+
+// Collect scrapes each member of the cluster in parallel and sends the aggregated metrics to the channel.
+func (c *CARPClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	// Scrape the CARP virtual IPs from each node in the cluster
+	nodes := make([]carpClusterNode, len(c.Pair.Targets))
+	var wg sync.WaitGroup
+	for idx, host := range c.Pair.Targets {
+		wg.Add(1)
+		go func(idx int, host string) {
+			defer wg.Done()
+			nodes[idx] = c.scrapeNode(host)
+		}(idx, host)
+	}
+	wg.Wait()
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Count the number of MASTER nodes for each VHID and track the slowest MASTER advertisement interval
+	masterIntervals := make(map[string]float64)
+	for _, node := range nodes {
+		c.carpClusterNodeUp.WithLabelValues(c.Pair.Name, node.host).Set(utils.BoolToFloat64(node.up))
+		for _, ip := range node.virtualIPs {
+			vhid := fmt.Sprintf("%d", ip.VHID)
+			isMaster := ip.CARPStatus == "master"
+			c.carpClusterMasters.WithLabelValues(c.Pair.Name, vhid, ip.Interface).Add(utils.BoolToFloat64(isMaster))
+			c.carpClusterMaster.WithLabelValues(c.Pair.Name, vhid, ip.Interface, node.host).Set(utils.BoolToFloat64(isMaster))
+
+			key := vhid + "/" + ip.Interface
+			if interval, ok := masterIntervals[key]; isMaster && (!ok || carpAdvInterval(ip) > interval) {
+				masterIntervals[key] = carpAdvInterval(ip)
+			}
+		}
+	}
+
+	// A BACKUP node advertising more frequently than the MASTER would normally be preferred, which usually
+	// means its skew was misconfigured or it will take over as soon as preemption occurs
+	for _, node := range nodes {
+		for _, ip := range node.virtualIPs {
+			if ip.CARPStatus != "backup" {
+				continue
+			}
+			vhid := fmt.Sprintf("%d", ip.VHID)
+			interval, ok := masterIntervals[vhid+"/"+ip.Interface]
+			c.carpClusterSkew.WithLabelValues(c.Pair.Name, vhid, ip.Interface, node.host).Set(
+				utils.BoolToFloat64(ok && carpAdvInterval(ip) < interval),
+			)
+		}
+	}
+
+	// Collect the metrics
+	c.carpClusterNodeUp.Collect(ch)
+	c.carpClusterMasters.Collect(ch)
+	c.carpClusterMaster.Collect(ch)
+	c.carpClusterSkew.Collect(ch)
+}
+
+// scrapeNode fetches the CARP virtual IP statuses from a single member of the cluster.
+func (c *CARPClusterCollector) scrapeNode(host string) carpClusterNode {
+	node := carpClusterNode{host: host}
+
+	target, err := utils.GetTarget(host)
+	if err != nil {
+		log.Error("carp_cluster", "failed to find target %s for cluster %s: %s", host, c.Pair.Name, err.Error())
+		return node
+	}
+
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/virtual_ips?mode=carp", &node.virtualIPs); err != nil {
+		log.Error("carp_cluster", "failed to fetch virtual IP status from host %s: %s", host, err.Error())
+		return node
+	}
+
+	node.up = true
+	return node
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *CARPClusterCollector) resetMetrics() {
+	c.carpClusterNodeUp.Reset()
+	c.carpClusterMasters.Reset()
+	c.carpClusterMaster.Reset()
+	c.carpClusterSkew.Reset()
+}
+
+// carpAdvInterval returns the effective CARP advertisement interval of a virtual IP in seconds. The node with
+// the lowest interval is preferred as MASTER.
+func carpAdvInterval(ip CARPVirtualIPStatus) float64 {
+	return float64(ip.AdvBase) + float64(ip.AdvSkew)/256
+}
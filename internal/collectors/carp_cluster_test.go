@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+func TestCARPClusterCollectorCollect(t *testing.T) {
+	// Save original Cfg and restore it after test
+	originalCfg := utils.Cfg
+	defer func() { utils.Cfg = originalCfg }()
+
+	// Both nodes claim to be MASTER for VHID 1 (split-brain), and neither is MASTER for VHID 2
+	node1 := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/virtual_ips": `[
+			{"carp_status":"master","uniqid":"vip1","subnet":"10.0.0.1","vhid":1,"interface":"wan"},
+			{"carp_status":"backup","uniqid":"vip2","subnet":"10.0.1.1","vhid":2,"interface":"lan"}
+		]`,
+	})
+	node2 := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/virtual_ips": `[
+			{"carp_status":"master","uniqid":"vip1","subnet":"10.0.0.1","vhid":1,"interface":"wan"},
+			{"carp_status":"backup","uniqid":"vip2","subnet":"10.0.1.1","vhid":2,"interface":"lan"}
+		]`,
+	})
+	node2.Host = "localhost"
+	utils.Cfg = &utils.Config{Targets: []utils.Target{*node1, *node2}}
+
+	collector := NewCARPClusterCollector(&utils.HAPair{Name: "edge", Targets: []string{node1.Host, node2.Host}})
+	samples := readTestSamples(t, collector.Collect)
+
+	// Verify the number of masters for each VHID
+	if count := countTestSamples(samples, "pfsense_carp_cluster_masters"); count != 2 {
+		t.Errorf("Expected 2 VHID master counts, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_masters", map[string]string{"vhid": "1", "interface": "wan"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 masters for VHID 1, got %f", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_masters", map[string]string{"vhid": "2", "interface": "lan"}); !ok || sample.value != 0 {
+		t.Errorf("Expected 0 masters for VHID 2, got %f", sample.value)
+	}
+
+	// Verify each node is reported as up with its own master status
+	if count := countTestSamples(samples, "pfsense_carp_cluster_master"); count != 4 {
+		t.Errorf("Expected 4 per-node master metrics, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_node_up", map[string]string{"node": "localhost"}); !ok || sample.value != 1 {
+		t.Errorf("Expected node localhost to be up, got %f", sample.value)
+	}
+}
+
+func TestCARPClusterCollectorCollectSkewInverted(t *testing.T) {
+	// Save original Cfg and restore it after test
+	originalCfg := utils.Cfg
+	defer func() { utils.Cfg = originalCfg }()
+
+	// The BACKUP node's skew is lower than the MASTER's for VHID 1, but not for VHID 2
+	node1 := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/virtual_ips": `[
+			{"carp_status":"master","uniqid":"vip1","vhid":1,"interface":"wan","advbase":1,"advskew":100},
+			{"carp_status":"master","uniqid":"vip2","vhid":2,"interface":"lan","advbase":1,"advskew":0}
+		]`,
+	})
+	node2 := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/virtual_ips": `[
+			{"carp_status":"backup","uniqid":"vip1","vhid":1,"interface":"wan","advbase":1,"advskew":0},
+			{"carp_status":"backup","uniqid":"vip2","vhid":2,"interface":"lan","advbase":1,"advskew":100}
+		]`,
+	})
+	node2.Host = "localhost"
+	utils.Cfg = &utils.Config{Targets: []utils.Target{*node1, *node2}}
+
+	collector := NewCARPClusterCollector(&utils.HAPair{Name: "edge", Targets: []string{node1.Host, node2.Host}})
+	samples := readTestSamples(t, collector.Collect)
+
+	if count := countTestSamples(samples, "pfsense_carp_cluster_skew_inverted"); count != 2 {
+		t.Errorf("Expected 2 skew inversion metrics, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_skew_inverted", map[string]string{"vhid": "1", "node": "localhost"}); !ok || sample.value != 1 {
+		t.Errorf("Expected skew to be inverted for VHID 1, got %f", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_skew_inverted", map[string]string{"vhid": "2", "node": "localhost"}); !ok || sample.value != 0 {
+		t.Errorf("Expected skew not to be inverted for VHID 2, got %f", sample.value)
+	}
+}
+
+func TestCARPClusterCollectorCollectNodeDown(t *testing.T) {
+	// Save original Cfg and restore it after test
+	originalCfg := utils.Cfg
+	defer func() { utils.Cfg = originalCfg }()
+
+	node1 := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/virtual_ips": `[{"carp_status":"master","uniqid":"vip1","subnet":"10.0.0.1","vhid":1,"interface":"wan"}]`,
+	})
+	utils.Cfg = &utils.Config{Targets: []utils.Target{*node1}}
+
+	// The second node isn't a configured target, so it can't be scraped
+	collector := NewCARPClusterCollector(&utils.HAPair{Name: "edge", Targets: []string{node1.Host, "missing.example.com"}})
+
+	samples := readTestSamples(t, collector.Collect)
+
+	if count := countTestSamples(samples, "pfsense_carp_cluster_node_up"); count != 2 {
+		t.Errorf("Expected 2 node up metrics, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_node_up", map[string]string{"node": "missing.example.com"}); !ok || sample.value != 0 {
+		t.Errorf("Expected missing node to be down, got %f", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_cluster_masters", map[string]string{"vhid": "1"}); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 master for VHID 1, got %f", sample.value)
+	}
+}
@@ -55,9 +55,9 @@ func TestCARPCollectorDescribe(t *testing.T) {
 		count++
 	}
 
-	// Should have 2 descriptions (carpEnabled and carpMaintenanceModeEnabled)
-	if count != 2 {
-		t.Errorf("Expected 2 metric descriptions, got %d", count)
+	// Should have 8 descriptions
+	if count != 8 {
+		t.Errorf("Expected 8 metric descriptions, got %d", count)
 	}
 }
 
@@ -124,6 +124,64 @@ func TestCARPCollectorCollectWithTarget(t *testing.T) {
 	}
 }
 
+func TestCARPCollectorCollectWithTargetHASync(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/status/carp":          `{"enable":true,"maintenance_mode":false}`,
+		"/api/v2/firewall/virtual_ips": `[{"carp_status":"backup","uniqid":"vip1","subnet":"10.0.0.1","vhid":1,"interface":"wan","advskew":100,"advbase":1}]`,
+		"/api/v2/system/hasync": `{"pfsyncenabled":true,"pfsyncinterface":"lan","pfsyncpeerip":"10.0.1.2",
+			"synchronizetoip":"10.0.1.2","username":"admin"}`,
+	})
+
+	samples := collectTestSamples(t, NewCARPCollector(), target)
+
+	// Verify the advertisement settings of the virtual IP
+	if sample, ok := findTestSample(samples, "pfsense_carp_virtual_ip_advskew", map[string]string{"uniqid": "vip1", "vhid": "1"}); !ok || sample.value != 100 {
+		t.Errorf("Expected advskew 100, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_carp_virtual_ip_advbase_seconds", map[string]string{"uniqid": "vip1"}); !ok || sample.value != 1 {
+		t.Errorf("Expected advbase 1, got %v", sample.value)
+	}
+
+	// Verify the pfsync and XMLRPC sync settings
+	if _, ok := findTestSample(samples, "pfsense_carp_pfsync_info", map[string]string{"interface": "lan", "peer_ip": "10.0.1.2"}); !ok {
+		t.Error("Expected pfsync info metric")
+	}
+	if _, ok := findTestSample(samples, "pfsense_carp_config_sync_info", map[string]string{"sync_to_ip": "10.0.1.2", "username": "admin"}); !ok {
+		t.Error("Expected config sync info metric")
+	}
+
+}
+
+func TestCARPCollectorCollectWithTargetNoHASync(t *testing.T) {
+	// The HA sync endpoint is missing, which should not prevent the CARP metrics
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/status/carp":          `{"enable":true,"maintenance_mode":false}`,
+		"/api/v2/firewall/virtual_ips": `[{"carp_status":"master","uniqid":"vip1","subnet":"10.0.0.1","vhid":1,"interface":"wan"}]`,
+	})
+
+	samples := collectTestSamples(t, NewCARPCollector(), target)
+
+	if count := countTestSamples(samples, "pfsense_carp_virtual_ip_status"); count != 1 {
+		t.Errorf("Expected 1 virtual IP status metric, got %d", count)
+	}
+	if count := countTestSamples(samples, "pfsense_carp_pfsync_enabled"); count != 0 {
+		t.Errorf("Expected no pfsync enabled metric, got %d", count)
+	}
+}
+
+func TestCARPCollectorCollectWithTargetVirtualIPError(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/status/carp":   `{"enable":true,"maintenance_mode":false}`,
+		"/api/v2/system/hasync": `{"pfsyncenabled":true,"pfsyncinterface":"lan","pfsyncpeerip":"10.0.1.2"}`,
+	})
+
+	// Without the virtual IPs, none of the CARP metrics should be reported
+	samples := collectTestSamples(t, NewCARPCollector(), target)
+	if len(samples) != 0 {
+		t.Errorf("Expected no metrics when the virtual IPs can't be fetched, got %d", len(samples))
+	}
+}
+
 func TestCARPCollectorCollectWithTargetError(t *testing.T) {
 	// Test with unreachable target to trigger error handling
 	target := &utils.Target{
@@ -0,0 +1,125 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// testSample is a flattened representation of a collected metric used for assertions.
+type testSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// newTestTarget starts a test server that responds to each request path with the given response data
+// and returns a Target pointing at it. Paths without a response receive a 404 API response.
+func newTestTarget(t *testing.T, responses map[string]string) *utils.Target {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data, ok := responses[r.URL.Path]
+		if !ok {
+			fmt.Fprint(w, `{"code": 404, "status": "not found", "message": "Endpoint not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"code": 200, "status": "ok", "data": %s}`, data)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	return &utils.Target{
+		Host:                   serverURL.Hostname(),
+		Port:                   port,
+		Scheme:                 serverURL.Scheme,
+		Username:               "test",
+		Password:               "test",
+		AuthMethod:             "basic",
+		Timeout:                30,
+		MaxCollectorBufferSize: 100,
+	}
+}
+
+// collectTestSamples runs a collector against a target and returns the samples it produced.
+func collectTestSamples(t *testing.T, collector registry.TargetedCollector, target *utils.Target) []testSample {
+	return readTestSamples(t, func(ch chan<- prometheus.Metric) {
+		collector.CollectWithTarget(ch, target)
+	})
+}
+
+// readTestSamples runs a collect function and returns the samples it produced.
+func readTestSamples(t *testing.T, collect func(ch chan<- prometheus.Metric)) []testSample {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		collect(ch)
+		close(ch)
+	}()
+
+	var samples []testSample
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatalf("Failed to write metric: %v", err)
+		}
+
+		// Extract the metric name from the descriptor
+		desc := metric.Desc().String()
+		name := strings.SplitN(strings.TrimPrefix(desc, `Desc{fqName: "`), `"`, 2)[0]
+
+		sample := testSample{name: name, labels: make(map[string]string)}
+		for _, label := range m.GetLabel() {
+			sample.labels[label.GetName()] = label.GetValue()
+		}
+		switch {
+		case m.Gauge != nil:
+			sample.value = m.GetGauge().GetValue()
+		case m.Counter != nil:
+			sample.value = m.GetCounter().GetValue()
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples
+}
+
+// findTestSample returns the first sample with the given name whose labels include all the given labels.
+func findTestSample(samples []testSample, name string, labels map[string]string) (testSample, bool) {
+	for _, sample := range samples {
+		if sample.name != name {
+			continue
+		}
+		matches := true
+		for key, value := range labels {
+			if sample.labels[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return sample, true
+		}
+	}
+	return testSample{}, false
+}
+
+// countTestSamples returns the number of samples with the given name.
+func countTestSamples(samples []testSample, name string) int {
+	count := 0
+	for _, sample := range samples {
+		if sample.name == name {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,146 @@
+package collectors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// configHistoryNow returns the current time. It is a variable so tests can control the time revisions are compared to.
+var configHistoryNow = time.Now
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewConfigHistoryCollector())
+}
+
+// ConfigHistoryCollector collects metrics about pfSense's configuration revision history.
+type ConfigHistoryCollector struct {
+	configLastChangeTimestamp *prometheus.GaugeVec
+	configLastChangeInfo      *prometheus.GaugeVec
+	configRevisionsCount      *prometheus.GaugeVec
+	configRevisionsLast24h    *prometheus.GaugeVec
+}
+
+// ConfigRevisionStats represents the structure of the config history revision data returned by the API.
+type ConfigRevisionStats struct {
+	Time        int64  `json:"time"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// NewConfigHistoryCollector is the constructor
+func NewConfigHistoryCollector() *ConfigHistoryCollector {
+	return &ConfigHistoryCollector{
+		configLastChangeTimestamp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "config_last_change_timestamp_seconds",
+				Help: "The Unix timestamp of the latest configuration revision.",
+			},
+			[]string{"host"},
+		),
+		configLastChangeInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "config_last_change_info",
+				Help: "Contains details about the user and description of the latest configuration revision.",
+			},
+			[]string{"host", "user", "description"},
+		),
+		configRevisionsCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "config_revisions_count",
+				Help: "The number of configuration revisions retained in the history.",
+			},
+			[]string{"host"},
+		),
+		configRevisionsLast24h: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "config_revisions_last_24h_count",
+				Help: "The number of configuration revisions made in the last 24 hours.",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *ConfigHistoryCollector) Name() string {
+	return "config_history"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *ConfigHistoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.configLastChangeTimestamp.Describe(ch)
+	c.configLastChangeInfo.Describe(ch)
+	c.configRevisionsCount.Describe(ch)
+	c.configRevisionsLast24h.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *ConfigHistoryCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the config revision history from the target
+	var revisions []ConfigRevisionStats
+	if err := utils.RequestData(target, "GET", "/api/v2/diagnostics/config_history/revisions", &revisions); err != nil {
+		log.Error("config_history", "failed to fetch config history from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Find the latest revision and count the revisions made in the last 24 hours
+	since := configHistoryNow().Add(-24 * time.Hour).Unix()
+	recent := 0
+	var latest *ConfigRevisionStats
+	for idx, revision := range revisions {
+		if revision.Time >= since {
+			recent++
+		}
+		if latest == nil || revision.Time > latest.Time {
+			latest = &revisions[idx]
+		}
+	}
+	c.configRevisionsCount.WithLabelValues(target.Host).Set(float64(len(revisions)))
+	c.configRevisionsLast24h.WithLabelValues(target.Host).Set(float64(recent))
+
+	// Only report the latest change when there is at least one revision
+	if latest != nil {
+		user, description := configRevisionUser(latest.Description)
+		c.configLastChangeTimestamp.WithLabelValues(target.Host).Set(float64(latest.Time))
+		c.configLastChangeInfo.WithLabelValues(target.Host, user, description).Set(1)
+	}
+
+	// Collect the metrics
+	c.configLastChangeTimestamp.Collect(ch)
+	c.configLastChangeInfo.Collect(ch)
+	c.configRevisionsCount.Collect(ch)
+	c.configRevisionsLast24h.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *ConfigHistoryCollector) resetMetrics() {
+	c.configLastChangeTimestamp.Reset()
+	c.configLastChangeInfo.Reset()
+	c.configRevisionsCount.Reset()
+	c.configRevisionsLast24h.Reset()
+}
+
+// configRevisionUser splits a revision description into the user who made the change and the description of the
+// change. pfSense prefixes descriptions with the user and their authentication source, e.g.
+// 'admin@192.168.1.100 (Local Database): Firewall: Rules - saved/edited a firewall rule.'
+func configRevisionUser(description string) (string, string) {
+	prefix, rest, found := strings.Cut(description, ": ")
+	if !found || !strings.Contains(prefix, "@") {
+		return "", description
+	}
+
+	// Remove the authentication source from the user
+	if idx := strings.Index(prefix, " ("); idx != -1 {
+		prefix = prefix[:idx]
+	}
+	return prefix, rest
+}
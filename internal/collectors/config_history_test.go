@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigHistoryCollectorCollectWithTarget(t *testing.T) {
+	// Fix the current time so the revisions in the last 24 hours are predictable
+	originalNow := configHistoryNow
+	defer func() { configHistoryNow = originalNow }()
+	configHistoryNow = func() time.Time { return time.Unix(1700100000, 0) }
+
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/diagnostics/config_history/revisions": `[
+			{"time":1700090000,"description":"admin@192.168.1.100 (Local Database): Firewall: Rules - saved/edited a firewall rule.","version":"23.3"},
+			{"time":1700050000,"description":"admin@192.168.1.100 (Local Database): Interfaces - saved.","version":"23.3"},
+			{"time":1690000000,"description":"(system): Upgraded config version","version":"23.3"}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewConfigHistoryCollector(), target)
+
+	if sample, ok := findTestSample(samples, "pfsense_config_last_change_timestamp_seconds", nil); !ok || sample.value != 1700090000 {
+		t.Errorf("Expected last change at 1700090000, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_config_revisions_count", nil); !ok || sample.value != 3 {
+		t.Errorf("Expected 3 revisions, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_config_revisions_last_24h_count", nil); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 revisions in the last 24 hours, got %v", sample.value)
+	}
+	labels := map[string]string{"user": "admin@192.168.1.100", "description": "Firewall: Rules - saved/edited a firewall rule."}
+	if _, ok := findTestSample(samples, "pfsense_config_last_change_info", labels); !ok {
+		t.Error("Expected last change info metric for the latest revision")
+	}
+}
+
+func TestConfigRevisionUser(t *testing.T) {
+	tests := []struct {
+		input       string
+		user        string
+		description string
+	}{
+		{"admin@192.168.1.100 (Local Database): Interfaces - saved.", "admin@192.168.1.100", "Interfaces - saved."},
+		{"jdoe@10.0.0.5 (LDAP): Firewall: Rules - applied.", "jdoe@10.0.0.5", "Firewall: Rules - applied."},
+		{"(system): Upgraded config version", "", "(system): Upgraded config version"},
+		{"No user", "", "No user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			user, description := configRevisionUser(tt.input)
+			if user != tt.user || description != tt.description {
+				t.Errorf("Expected '%s' and '%s', got '%s' and '%s'", tt.user, tt.description, user, description)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// statCounter describes a cumulative statistic of T exposed as a Prometheus counter.
+type statCounter[T any] struct {
+	name  string
+	desc  *prometheus.Desc
+	value func(stats T) float64
+}
+
+// newStatCounter creates a statCounter for a cumulative statistic of T.
+func newStatCounter[T any](name string, help string, labels []string, value func(stats T) float64) statCounter[T] {
+	return statCounter[T]{
+		name:  name,
+		desc:  prometheus.NewDesc(registry.MetricsPrefix+name, help, labels, nil),
+		value: value,
+	}
+}
+
+// counterTracker keeps counters monotonic when the underlying values reported by the API are reset.
+type counterTracker struct {
+	counters map[string]map[string]*trackedCounter
+	mu       sync.Mutex
+}
+
+// trackedCounter holds the last raw value observed for a counter and the total carried over from previous resets.
+type trackedCounter struct {
+	last   float64
+	offset float64
+	seen   bool
+}
+
+// newCounterTracker is the constructor
+func newCounterTracker() *counterTracker {
+	return &counterTracker{counters: make(map[string]map[string]*trackedCounter)}
+}
+
+// Observe records a raw counter value under the given host and key and returns the monotonic total. When the raw
+// value drops below the previous observation (e.g. after a reboot or a counter reset on pfSense), the
+// previous value is carried forward so the returned total never decreases.
+func (t *counterTracker) Observe(host string, key string, value float64) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Start tracking the counter the first time it is seen
+	counters, ok := t.counters[host]
+	if !ok {
+		counters = make(map[string]*trackedCounter)
+		t.counters[host] = counters
+	}
+	counter, ok := counters[key]
+	if !ok {
+		counters[key] = &trackedCounter{last: value, seen: true}
+		return value
+	}
+
+	// Carry the previous value forward if the counter was reset
+	if value < counter.last {
+		counter.offset += counter.last
+	}
+	counter.last = value
+	counter.seen = true
+
+	return counter.offset + value
+}
+
+// Prune stops tracking the counters of a host that were not observed since the previous call, e.g. counters of
+// interfaces that were removed. It should be called once per scrape after all counters were observed.
+func (t *counterTracker) Prune(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, counter := range t.counters[host] {
+		if !counter.seen {
+			delete(t.counters[host], key)
+			continue
+		}
+		counter.seen = false
+	}
+	if len(t.counters[host]) == 0 {
+		delete(t.counters, host)
+	}
+}
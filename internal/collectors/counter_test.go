@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestCounterTrackerObserve(t *testing.T) {
+	tracker := newCounterTracker()
+
+	// The first observation should be returned as-is
+	if total := tracker.Observe("host", "a", 100); total != 100 {
+		t.Errorf("Expected 100 on first observation, got %f", total)
+	}
+
+	// Increasing values should be returned as-is
+	if total := tracker.Observe("host", "a", 150); total != 150 {
+		t.Errorf("Expected 150 after increase, got %f", total)
+	}
+
+	// A reset should carry the previous value forward
+	if total := tracker.Observe("host", "a", 20); total != 170 {
+		t.Errorf("Expected 170 after reset, got %f", total)
+	}
+	if total := tracker.Observe("host", "a", 30); total != 180 {
+		t.Errorf("Expected 180 after increase following reset, got %f", total)
+	}
+
+	// Other keys and hosts should be tracked separately
+	if total := tracker.Observe("host", "b", 5); total != 5 {
+		t.Errorf("Expected 5 for a separate key, got %f", total)
+	}
+	if total := tracker.Observe("other", "a", 7); total != 7 {
+		t.Errorf("Expected 7 for a separate host, got %f", total)
+	}
+}
+
+func TestCounterTrackerPrune(t *testing.T) {
+	tracker := newCounterTracker()
+
+	// Build up an offset on both keys
+	tracker.Observe("host", "a", 100)
+	tracker.Observe("host", "a", 10)
+	tracker.Observe("host", "b", 100)
+	tracker.Observe("host", "b", 10)
+	tracker.Observe("other", "a", 100)
+	tracker.Prune("host")
+
+	// Only observe 'a' during the next scrape
+	tracker.Observe("host", "a", 20)
+	tracker.Prune("host")
+
+	// 'b' was not seen in the previous scrape, so it should start over
+	if total := tracker.Observe("host", "b", 5); total != 5 {
+		t.Errorf("Expected pruned counter to start over at 5, got %f", total)
+	}
+	if total := tracker.Observe("host", "a", 30); total != 130 {
+		t.Errorf("Expected counter seen in the previous scrape to keep its offset, got %f", total)
+	}
+
+	// Pruning a host should not affect other hosts
+	if total := tracker.Observe("other", "a", 120); total != 120 {
+		t.Errorf("Expected counter of another host to be kept, got %f", total)
+	}
+}
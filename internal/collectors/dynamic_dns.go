@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewDynamicDNSCollector())
+}
+
+// DynamicDNSCollector collects metrics about dynamic DNS clients.
+type DynamicDNSCollector struct {
+	dynamicDNSEnabled *prometheus.GaugeVec
+}
+
+// DynamicDNSStats represents the structure of the dynamic DNS client data returned by the API.
+type DynamicDNSStats struct {
+	Type       string `json:"type"`
+	Host       string `json:"host"`
+	DomainName string `json:"domainname"`
+	Interface  string `json:"interface"`
+	Enable     bool   `json:"enable"`
+}
+
+// NewDynamicDNSCollector is the constructor
+func NewDynamicDNSCollector() *DynamicDNSCollector {
+	return &DynamicDNSCollector{
+		dynamicDNSEnabled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "dynamic_dns_enabled",
+				Help: "Whether the dynamic DNS client is enabled (1) or not (0).",
+			},
+			[]string{"host", "provider", "hostname", "interface"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *DynamicDNSCollector) Name() string {
+	return "dynamic_dns"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *DynamicDNSCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.dynamicDNSEnabled.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *DynamicDNSCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the dynamic DNS clients from the target
+	var clients []DynamicDNSStats
+	if err := utils.RequestData(target, "GET", "/api/v2/services/dynamic_dns/clients", &clients); err != nil {
+		log.Error("dynamic_dns", "failed to fetch dynamic DNS clients from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each dynamic DNS client identified
+	for _, client := range clients {
+		hostname := dynamicDNSHostname(client)
+		c.dynamicDNSEnabled.WithLabelValues(target.Host, client.Type, hostname, client.Interface).Set(utils.BoolToFloat64(client.Enable))
+	}
+
+	// Collect the metrics
+	c.dynamicDNSEnabled.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *DynamicDNSCollector) resetMetrics() {
+	c.dynamicDNSEnabled.Reset()
+}
+
+// dynamicDNSHostname determines the fully qualified hostname updated by a dynamic DNS client. Some providers
+// split the hostname into a host and domain name, while others only use the host.
+func dynamicDNSHostname(client DynamicDNSStats) string {
+	switch {
+	case client.DomainName == "" || strings.HasSuffix(client.Host, client.DomainName):
+		return client.Host
+	case client.Host == "" || client.Host == "@":
+		return client.DomainName
+	default:
+		return client.Host + "." + client.DomainName
+	}
+}
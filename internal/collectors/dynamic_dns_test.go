@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestDynamicDNSCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/services/dynamic_dns/clients": `[
+			{"type":"cloudflare","host":"branch1","domainname":"example.com","interface":"wan","enable":true},
+			{"type":"dyndns","host":"branch1.dyndns.org","domainname":"","interface":"wan","enable":true},
+			{"type":"noip","host":"branch2.ddns.net","domainname":"","interface":"opt1","enable":false}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewDynamicDNSCollector(), target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_dynamic_dns_enabled", map[string]string{"provider": "cloudflare", "hostname": "branch1.example.com", "interface": "wan"}, 1},
+		{"pfsense_dynamic_dns_enabled", map[string]string{"provider": "dyndns", "hostname": "branch1.dyndns.org", "interface": "wan"}, 1},
+		{"pfsense_dynamic_dns_enabled", map[string]string{"provider": "noip", "hostname": "branch2.ddns.net", "interface": "opt1"}, 0},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+}
+
+func TestDynamicDNSHostname(t *testing.T) {
+	tests := []struct {
+		client   DynamicDNSStats
+		hostname string
+	}{
+		{DynamicDNSStats{Host: "branch1", DomainName: "example.com"}, "branch1.example.com"},
+		{DynamicDNSStats{Host: "branch1.example.com", DomainName: "example.com"}, "branch1.example.com"},
+		{DynamicDNSStats{Host: "branch1.dyndns.org"}, "branch1.dyndns.org"},
+		{DynamicDNSStats{Host: "@", DomainName: "example.com"}, "example.com"},
+	}
+
+	for _, tt := range tests {
+		if hostname := dynamicDNSHostname(tt.client); hostname != tt.hostname {
+			t.Errorf("Expected hostname '%s' for %+v, got '%s'", tt.hostname, tt.client, hostname)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewFirewallLogCollector())
+}
+
+// FirewallLogCollector collects metrics about the packets logged by firewall rules.
+type FirewallLogCollector struct {
+	firewallLogPackets       *prometheus.Desc
+	firewallLogUnparsedLines *prometheus.Desc
+	cursor                   *logCursor
+	packets                  *logTotals[firewallLogKey]
+	unparsed                 *logTotals[string]
+}
+
+// FirewallLogEntry represents a parsed filterlog line from the firewall log.
+type FirewallLogEntry struct {
+	Tracker     string
+	Interface   string
+	Action      string
+	Direction   string
+	Protocol    string
+	Source      string
+	Destination string
+}
+
+// firewallLogKey identifies the label values firewall log packets are counted by.
+type firewallLogKey struct {
+	iface    string
+	action   string
+	protocol string
+	tracker  string
+}
+
+// NewFirewallLogCollector is the constructor
+func NewFirewallLogCollector() *FirewallLogCollector {
+	return &FirewallLogCollector{
+		firewallLogPackets: prometheus.NewDesc(
+			registry.MetricsPrefix+"firewall_log_packets_total",
+			"The number of packets logged by the firewall by interface, action, protocol and rule tracker ID.",
+			[]string{"host", "interface", "action", "protocol", "tracker"},
+			nil,
+		),
+		firewallLogUnparsedLines: prometheus.NewDesc(
+			registry.MetricsPrefix+"firewall_log_unparsed_lines_total",
+			"The number of firewall log lines that could not be parsed.",
+			[]string{"host"},
+			nil,
+		),
+		cursor:   newLogCursor(),
+		packets:  newLogTotals[firewallLogKey](),
+		unparsed: newLogTotals[string](),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *FirewallLogCollector) Name() string {
+	return "firewall_log"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *FirewallLogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.firewallLogPackets
+	ch <- c.firewallLogUnparsedLines
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *FirewallLogCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the firewall log from the target
+	lines, err := fetchLogLines(target, "firewall")
+	if err != nil {
+		log.Error("firewall_log", "failed to fetch firewall log from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Count the packets logged since the previous scrape
+	packets := make(map[firewallLogKey]float64)
+	unparsed := 0
+	for _, line := range c.cursor.Advance(target.Host, lines) {
+		entry, ok := parseFirewallLogLine(line)
+		if !ok {
+			unparsed++
+			continue
+		}
+		packets[firewallLogKey{entry.Interface, entry.Action, entry.Protocol, entry.Tracker}]++
+	}
+
+	// Emit the accumulated totals as counters
+	for key, total := range c.packets.Add(target.Host, packets) {
+		ch <- prometheus.MustNewConstMetric(c.firewallLogPackets, prometheus.CounterValue, total, target.Host, key.iface, key.action, key.protocol, key.tracker)
+	}
+	totals := c.unparsed.Add(target.Host, map[string]float64{"": float64(unparsed)})
+	ch <- prometheus.MustNewConstMetric(c.firewallLogUnparsedLines, prometheus.CounterValue, totals[""], target.Host)
+}
+
+// parseFirewallLogLine parses a filterlog line from the firewall log. The line's CSV fields are documented at
+// https://docs.netgate.com/pfsense/en/latest/monitoring/logs/raw-filter-format.html
+func parseFirewallLogLine(line string) (FirewallLogEntry, bool) {
+	// Strip the syslog prefix from the CSV fields
+	idx := strings.Index(line, "filterlog")
+	if idx == -1 {
+		return FirewallLogEntry{}, false
+	}
+	_, csv, found := strings.Cut(line[idx:], ": ")
+	if !found {
+		return FirewallLogEntry{}, false
+	}
+	fields := strings.Split(csv, ",")
+	if len(fields) < 9 {
+		return FirewallLogEntry{}, false
+	}
+
+	entry := FirewallLogEntry{
+		Tracker:   fields[3],
+		Interface: fields[4],
+		Action:    fields[6],
+		Direction: fields[7],
+	}
+
+	// The position of the protocol and addresses depends on the IP version
+	switch fields[8] {
+	case "4":
+		if len(fields) < 20 {
+			return FirewallLogEntry{}, false
+		}
+		entry.Protocol, entry.Source, entry.Destination = fields[16], fields[18], fields[19]
+	case "6":
+		if len(fields) < 17 {
+			return FirewallLogEntry{}, false
+		}
+		entry.Protocol, entry.Source, entry.Destination = fields[12], fields[15], fields[16]
+	default:
+		return FirewallLogEntry{}, false
+	}
+	return entry, true
+}
@@ -0,0 +1,106 @@
+package collectors
+
+import (
+	"testing"
+)
+
+// Sample filterlog lines for IPv4 and IPv6 traffic
+const (
+	testFirewallLogBlockV4      = `Oct 18 12:00:00 pfSense filterlog[12345]: 5,,,1000000103,igb1,match,block,in,4,0x0,,64,12345,0,DF,6,tcp,60,203.0.113.5,198.51.100.1,51234,22,0,S,123456789,,64240,,mss;sackOK;TS;nop;wscale`
+	testFirewallLogBlockV4Later = `Oct 18 12:00:05 pfSense filterlog[12345]: 5,,,1000000103,igb1,match,block,in,4,0x0,,64,12346,0,DF,6,tcp,60,203.0.113.5,198.51.100.1,51235,22,0,S,123456790,,64240,,mss;sackOK;TS;nop;wscale`
+	testFirewallLogPassV4       = `Oct 18 12:00:01 pfSense filterlog[12345]: 7,,,1770001234,igb0,match,pass,in,4,0x0,,64,23456,0,none,17,udp,76,192.168.1.10,192.168.1.1,40000,53,56`
+	testFirewallLogBlockV6      = `Oct 18 12:00:02 pfSense filterlog[12345]: 5,,,1000000103,igb1,match,block,in,6,0x00,0x00000,255,ipv6-icmp,58,32,2001:db8::1,2001:db8::2,`
+)
+
+func TestFirewallLogCollectorCollectWithTarget(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/status/logs/firewall": `[{"text":"` + testFirewallLogBlockV4 + `"}]`,
+	}
+	target := newTestTarget(t, responses)
+	collector := NewFirewallLogCollector()
+
+	// The first scrape should only position the cursor
+	samples := collectTestSamples(t, collector, target)
+	if count := countTestSamples(samples, "pfsense_firewall_log_packets_total"); count != 0 {
+		t.Errorf("Expected no packet counters on first scrape, got %d", count)
+	}
+
+	// Lines logged after the first scrape should be counted
+	responses["/api/v2/status/logs/firewall"] = `[
+		{"text":"` + testFirewallLogBlockV4 + `"},
+		{"text":"` + testFirewallLogBlockV4Later + `"},
+		{"text":"` + testFirewallLogPassV4 + `"},
+		{"text":"` + testFirewallLogBlockV6 + `"},
+		{"text":"Oct 18 12:00:03 pfSense filterlog[12345]: garbage"}
+	]`
+	samples = collectTestSamples(t, collector, target)
+
+	tests := []struct {
+		labels map[string]string
+		value  float64
+	}{
+		{map[string]string{"interface": "igb1", "action": "block", "protocol": "tcp", "tracker": "1000000103"}, 1},
+		{map[string]string{"interface": "igb0", "action": "pass", "protocol": "udp", "tracker": "1770001234"}, 1},
+		{map[string]string{"interface": "igb1", "action": "block", "protocol": "ipv6-icmp", "tracker": "1000000103"}, 1},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, "pfsense_firewall_log_packets_total", tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %v for %v, got %v (found: %v)", tt.value, tt.labels, sample.value, ok)
+		}
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_log_unparsed_lines_total", nil); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 unparsed line, got %v", sample.value)
+	}
+
+	// Counters should keep accumulating across scrapes
+	responses["/api/v2/status/logs/firewall"] = `[
+		{"text":"` + testFirewallLogPassV4 + `"},
+		{"text":"` + testFirewallLogBlockV6 + `"},
+		{"text":"Oct 18 12:00:03 pfSense filterlog[12345]: garbage"},
+		{"text":"` + testFirewallLogPassV4 + `"}
+	]`
+	samples = collectTestSamples(t, collector, target)
+	labels := map[string]string{"interface": "igb0", "action": "pass", "protocol": "udp", "tracker": "1770001234"}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_log_packets_total", labels); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 passed packets after the third scrape, got %v", sample.value)
+	}
+
+	// Nothing should be counted once the position in the log is lost (e.g. the log was rotated)
+	responses["/api/v2/status/logs/firewall"] = `[{"text":"` + testFirewallLogBlockV4Later + `"}]`
+	samples = collectTestSamples(t, collector, target)
+	labels = map[string]string{"interface": "igb1", "action": "block", "protocol": "tcp", "tracker": "1000000103"}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_log_packets_total", labels); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 blocked packet after the log was rotated, got %v", sample.value)
+	}
+}
+
+func TestParseFirewallLogLine(t *testing.T) {
+	entry, ok := parseFirewallLogLine(testFirewallLogBlockV4)
+	if !ok {
+		t.Fatal("Expected IPv4 line to be parsed")
+	}
+	expected := FirewallLogEntry{
+		Tracker:     "1000000103",
+		Interface:   "igb1",
+		Action:      "block",
+		Direction:   "in",
+		Protocol:    "tcp",
+		Source:      "203.0.113.5",
+		Destination: "198.51.100.1",
+	}
+	if entry != expected {
+		t.Errorf("Expected %+v, got %+v", expected, entry)
+	}
+
+	entry, ok = parseFirewallLogLine(testFirewallLogBlockV6)
+	if !ok || entry.Protocol != "ipv6-icmp" || entry.Source != "2001:db8::1" || entry.Destination != "2001:db8::2" {
+		t.Errorf("Expected IPv6 line to be parsed, got %+v (ok: %v)", entry, ok)
+	}
+
+	for _, line := range []string{"", "Oct 18 12:00:00 pfSense sshd[1]: hello", "Oct 18 12:00:00 pfSense filterlog[1]: 5,,,1,igb1,match,block,in,4"} {
+		if _, ok := parseFirewallLogLine(line); ok {
+			t.Errorf("Expected line '%s' not to be parsed", line)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package collectors
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// firewallStatesTrackedAddresses is the maximum number of source or destination addresses tracked to determine the
+// top-N addresses, which bounds memory use when the state table contains many unique (e.g. spoofed) addresses.
+const firewallStatesTrackedAddresses = 10000
+
+// errFirewallStatesPageRepeated stops decoding a page of states that repeats the previous page.
+var errFirewallStatesPageRepeated = errors.New("firewall states page repeated")
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewFirewallStatesDetailCollector())
+}
+
+// FirewallStatesDetailCollector collects metrics about the contents of the firewall state table.
+type FirewallStatesDetailCollector struct {
+	firewallStatesDetailCount     *prometheus.GaugeVec
+	firewallStatesDetailTCPCount  *prometheus.GaugeVec
+	firewallStatesDetailTopSource *prometheus.GaugeVec
+	firewallStatesDetailTopDest   *prometheus.GaugeVec
+	firewallStatesDetailScanned   *prometheus.GaugeVec
+	firewallStatesDetailPages     *prometheus.GaugeVec
+	firewallStatesDetailAddresses *prometheus.GaugeVec
+	firewallStatesDetailTruncated *prometheus.GaugeVec
+}
+
+// FirewallStateEntry represents the structure of a single firewall state returned by the API.
+type FirewallStateEntry struct {
+	Interface   string `json:"interface"`
+	Protocol    string `json:"protocol"`
+	Direction   string `json:"direction"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	State       string `json:"state"`
+}
+
+// firewallStatesSummary holds the aggregated counts of a target's firewall state table.
+type firewallStatesSummary struct {
+	total        int
+	pages        int
+	truncated    bool
+	counts       map[[3]string]int
+	tcpStates    map[string]int
+	sources      *firewallStatesAddresses
+	destinations *firewallStatesAddresses
+}
+
+// firewallStatesAddressCount is the number of states associated with a single address.
+type firewallStatesAddressCount struct {
+	address string
+	count   int
+	index   int // index is the position of the count in the firewallStatesAddresses heap.
+}
+
+// firewallStatesAddresses counts the states of a bounded number of addresses using the space-saving algorithm.
+// Once the maximum number of addresses is tracked, a new address replaces the address with the fewest states and
+// inherits its count, so addresses with many states are still reported even if they first appear late in the scan.
+type firewallStatesAddresses struct {
+	max     int
+	heap    firewallStatesAddressHeap
+	indexes map[string]*firewallStatesAddressCount
+}
+
+// firewallStatesAddressHeap is a min-heap of address counts, ordered by count.
+type firewallStatesAddressHeap []*firewallStatesAddressCount
+
+// NewFirewallStatesDetailCollector is the constructor
+func NewFirewallStatesDetailCollector() *FirewallStatesDetailCollector {
+	return &FirewallStatesDetailCollector{
+		firewallStatesDetailCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_count",
+				Help: "Number of firewall states by interface, protocol and direction.",
+			},
+			[]string{"host", "interface", "protocol", "direction"},
+		),
+		firewallStatesDetailTCPCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_tcp_count",
+				Help: "Number of TCP firewall states by TCP state.",
+			},
+			[]string{"host", "state"},
+		),
+		firewallStatesDetailTopSource: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_top_source_count",
+				Help: "Number of firewall states for each of the top-N source addresses.",
+			},
+			[]string{"host", "address"},
+		),
+		firewallStatesDetailTopDest: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_top_destination_count",
+				Help: "Number of firewall states for each of the top-N destination addresses.",
+			},
+			[]string{"host", "address"},
+		),
+		firewallStatesDetailScanned: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_scanned_count",
+				Help: "Number of firewall states scanned to build the breakdown.",
+			},
+			[]string{"host"},
+		),
+		firewallStatesDetailPages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_pages_count",
+				Help: "Number of pages requested from the API to scan the firewall state table.",
+			},
+			[]string{"host"},
+		),
+		firewallStatesDetailAddresses: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_unique_addresses_count",
+				Help: "Number of unique source or destination addresses in the firewall state table, up to the number of tracked addresses.",
+			},
+			[]string{"host", "direction"},
+		),
+		firewallStatesDetailTruncated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "firewall_states_detail_truncated",
+				Help: "Whether paging stopped before the end of the firewall state table (1 = truncated, 0 = complete).",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *FirewallStatesDetailCollector) Name() string {
+	return "firewall_states_detail"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *FirewallStatesDetailCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.firewallStatesDetailCount.Describe(ch)
+	c.firewallStatesDetailTCPCount.Describe(ch)
+	c.firewallStatesDetailTopSource.Describe(ch)
+	c.firewallStatesDetailTopDest.Describe(ch)
+	c.firewallStatesDetailScanned.Describe(ch)
+	c.firewallStatesDetailPages.Describe(ch)
+	c.firewallStatesDetailAddresses.Describe(ch)
+	c.firewallStatesDetailTruncated.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *FirewallStatesDetailCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Page through the state table, aggregating each state as it is decoded
+	summary, err := scanFirewallStates(target)
+	if err != nil {
+		log.Error("firewall_states_detail", "failed to fetch firewall states from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract the aggregated counts
+	for key, count := range summary.counts {
+		c.firewallStatesDetailCount.WithLabelValues(target.Host, key[0], key[1], key[2]).Set(float64(count))
+	}
+	for state, count := range summary.tcpStates {
+		c.firewallStatesDetailTCPCount.WithLabelValues(target.Host, state).Set(float64(count))
+	}
+	for _, top := range summary.sources.Top(target.FirewallStatesDetail.TopN) {
+		c.firewallStatesDetailTopSource.WithLabelValues(target.Host, top.address).Set(float64(top.count))
+	}
+	for _, top := range summary.destinations.Top(target.FirewallStatesDetail.TopN) {
+		c.firewallStatesDetailTopDest.WithLabelValues(target.Host, top.address).Set(float64(top.count))
+	}
+	c.firewallStatesDetailScanned.WithLabelValues(target.Host).Set(float64(summary.total))
+	c.firewallStatesDetailPages.WithLabelValues(target.Host).Set(float64(summary.pages))
+	c.firewallStatesDetailAddresses.WithLabelValues(target.Host, "source").Set(float64(summary.sources.Len()))
+	c.firewallStatesDetailAddresses.WithLabelValues(target.Host, "destination").Set(float64(summary.destinations.Len()))
+	c.firewallStatesDetailTruncated.WithLabelValues(target.Host).Set(utils.BoolToFloat64(summary.truncated))
+
+	// Collect the metrics
+	c.firewallStatesDetailCount.Collect(ch)
+	c.firewallStatesDetailTCPCount.Collect(ch)
+	c.firewallStatesDetailTopSource.Collect(ch)
+	c.firewallStatesDetailTopDest.Collect(ch)
+	c.firewallStatesDetailScanned.Collect(ch)
+	c.firewallStatesDetailPages.Collect(ch)
+	c.firewallStatesDetailAddresses.Collect(ch)
+	c.firewallStatesDetailTruncated.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *FirewallStatesDetailCollector) resetMetrics() {
+	c.firewallStatesDetailCount.Reset()
+	c.firewallStatesDetailTCPCount.Reset()
+	c.firewallStatesDetailTopSource.Reset()
+	c.firewallStatesDetailTopDest.Reset()
+	c.firewallStatesDetailScanned.Reset()
+	c.firewallStatesDetailPages.Reset()
+	c.firewallStatesDetailAddresses.Reset()
+	c.firewallStatesDetailTruncated.Reset()
+}
+
+// scanFirewallStates pages through the target's firewall state table and aggregates each state as it is streamed.
+func scanFirewallStates(target *utils.Target) (*firewallStatesSummary, error) {
+	summary := &firewallStatesSummary{
+		counts:       make(map[[3]string]int),
+		tcpStates:    make(map[string]int),
+		sources:      newFirewallStatesAddresses(firewallStatesTrackedAddresses),
+		destinations: newFirewallStatesAddresses(firewallStatesTrackedAddresses),
+	}
+
+	options := target.FirewallStatesDetail
+	pages, truncated, err := pageFirewallStates(target, options.PageSize, options.MaxStates, func(state FirewallStateEntry) {
+		summary.total++
+		summary.counts[[3]string{state.Interface, state.Protocol, state.Direction}]++
+		if strings.EqualFold(state.Protocol, "tcp") {
+			summary.tcpStates[state.State]++
+		}
+		summary.sources.Add(firewallStateAddress(state.Source))
+		summary.destinations.Add(firewallStateAddress(state.Destination))
+	})
+	summary.pages = pages
+	summary.truncated = truncated
+
+	return summary, err
+}
+
+// pageFirewallStates requests the target's firewall state table one page at a time and passes each state to fn.
+// Paging stops after maxStates states, or when a page repeats the previous one (e.g. if the API ignores the offset).
+// It returns the number of pages requested and whether paging stopped before the end of the state table.
+func pageFirewallStates(target *utils.Target, pageSize int, maxStates int, fn func(FirewallStateEntry)) (int, bool, error) {
+	// Fall back to the default page size and limit for targets that haven't been validated
+	if pageSize < 1 {
+		pageSize = 1000
+	}
+	if maxStates < 1 {
+		maxStates = 100000
+	}
+
+	var previous *FirewallStateEntry
+	for pages, offset := 1, 0; ; pages, offset = pages+1, offset+pageSize {
+		// Stream the page so only a single state is held in memory at a time
+		count := 0
+		var first *FirewallStateEntry
+		endpoint := fmt.Sprintf("/api/v2/firewall/states?limit=%d&offset=%d", pageSize, offset)
+		err := utils.RequestStream(target, "GET", endpoint, func(dec *json.Decoder) error {
+			return utils.DecodeArray(dec, func(state FirewallStateEntry) error {
+				// A page starting with the same state as the previous page is a repeat of that page
+				if first == nil {
+					first = &state
+					if previous != nil && *previous == state {
+						return errFirewallStatesPageRepeated
+					}
+				}
+				count++
+				fn(state)
+				return nil
+			})
+		})
+		if errors.Is(err, errFirewallStatesPageRepeated) {
+			log.Warn("firewall_states_detail", "stopped paging firewall states from host %s after a repeated page", target.Host)
+			return pages, true, nil
+		}
+		if err != nil {
+			return pages, false, err
+		}
+
+		// A partial page means the end of the state table has been reached
+		if count < pageSize {
+			return pages, false, nil
+		}
+		if offset+pageSize >= maxStates {
+			log.Warn("firewall_states_detail", "stopped paging firewall states from host %s after %d states", target.Host, maxStates)
+			return pages, true, nil
+		}
+		previous = first
+	}
+}
+
+// firewallStateAddress removes the port from a firewall state's source or destination (e.g. 192.168.1.1:443,
+// fe80::1[443] or [fe80::1]:443), leaving only the address.
+func firewallStateAddress(endpoint string) string {
+	address, _ := firewallStateEndpoint(endpoint)
+	return address
+}
+
+// firewallStateEndpoint splits a firewall state's source or destination into its address and port. Any
+// translated address pf reports after the endpoint (e.g. 192.168.1.10:80 (203.0.113.1:8080)) is ignored.
+func firewallStateEndpoint(endpoint string) (string, string) {
+	if fields := strings.Fields(endpoint); len(fields) > 0 {
+		endpoint = fields[0]
+	}
+
+	// Bracketed IPv6 address followed by a port
+	if strings.HasPrefix(endpoint, "[") {
+		if idx := strings.Index(endpoint, "]"); idx != -1 {
+			return endpoint[1:idx], strings.TrimPrefix(endpoint[idx+1:], ":")
+		}
+		return endpoint, ""
+	}
+
+	// pf style IPv6 address with the port in brackets
+	if idx := strings.Index(endpoint, "["); idx != -1 && strings.HasSuffix(endpoint, "]") {
+		return endpoint[:idx], endpoint[idx+1 : len(endpoint)-1]
+	}
+
+	// IPv4 address followed by a port. IPv6 addresses without a port contain multiple colons.
+	if strings.Count(endpoint, ":") == 1 {
+		idx := strings.Index(endpoint, ":")
+		return endpoint[:idx], endpoint[idx+1:]
+	}
+
+	return endpoint, ""
+}
+
+// newFirewallStatesAddresses is the constructor
+func newFirewallStatesAddresses(max int) *firewallStatesAddresses {
+	return &firewallStatesAddresses{max: max, indexes: make(map[string]*firewallStatesAddressCount)}
+}
+
+// Add counts a state for the address.
+func (a *firewallStatesAddresses) Add(address string) {
+	if count, ok := a.indexes[address]; ok {
+		count.count++
+		heap.Fix(&a.heap, count.index)
+		return
+	}
+	if len(a.heap) < a.max {
+		count := &firewallStatesAddressCount{address: address, count: 1}
+		heap.Push(&a.heap, count)
+		a.indexes[address] = count
+		return
+	}
+
+	// Replace the address with the fewest states
+	count := a.heap[0]
+	delete(a.indexes, count.address)
+	count.address = address
+	count.count++
+	a.indexes[address] = count
+	heap.Fix(&a.heap, 0)
+}
+
+// Len returns the number of addresses tracked.
+func (a *firewallStatesAddresses) Len() int {
+	return len(a.heap)
+}
+
+// Top returns the n addresses with the most states, ordered by count and then address.
+func (a *firewallStatesAddresses) Top(n int) []firewallStatesAddressCount {
+	counts := make([]firewallStatesAddressCount, 0, len(a.heap))
+	for _, count := range a.heap {
+		counts = append(counts, firewallStatesAddressCount{address: count.address, count: count.count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].address < counts[j].address
+	})
+
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface.
+func (h firewallStatesAddressHeap) Len() int           { return len(h) }
+func (h firewallStatesAddressHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h firewallStatesAddressHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *firewallStatesAddressHeap) Push(x any) {
+	count := x.(*firewallStatesAddressCount)
+	count.index = len(*h)
+	*h = append(*h, count)
+}
+func (h *firewallStatesAddressHeap) Pop() any {
+	old := *h
+	count := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return count
+}
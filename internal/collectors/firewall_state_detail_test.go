@@ -0,0 +1,242 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+// newFirewallStatesTestTarget starts a test server serving the states in pages of the requested size and returns a
+// Target pointing at it. If ignoreOffset is set, every request is served the first page.
+func newFirewallStatesTestTarget(t *testing.T, states []string, ignoreOffset bool, options utils.FirewallStatesDetailOptions) *utils.Target {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if ignoreOffset {
+			offset = 0
+		}
+		page := "["
+		for idx := offset; idx < offset+limit && idx < len(states); idx++ {
+			if idx > offset {
+				page += ","
+			}
+			page += states[idx]
+		}
+		fmt.Fprintf(w, `{"code": 200, "status": "ok", "data": %s]}`, page)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	return &utils.Target{
+		Host:                 serverURL.Hostname(),
+		Port:                 port,
+		Scheme:               serverURL.Scheme,
+		AuthMethod:           "basic",
+		Timeout:              30,
+		FirewallStatesDetail: options,
+	}
+}
+
+func TestFirewallStatesDetailCollectorCollectWithTarget(t *testing.T) {
+	states := []string{
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.5:40000","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}`,
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.5:40001","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}`,
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.6:40000","destination":"198.51.100.1:443","state":"SYN_SENT:CLOSED"}`,
+		`{"interface":"lan","protocol":"udp","direction":"out","source":"192.168.1.10:5353","destination":"192.168.1.1:53","state":"MULTIPLE:SINGLE"}`,
+		`{"interface":"lan","protocol":"icmp","direction":"out","source":"2001:db8::1[1234]","destination":"2001:db8::2[1234]","state":"0:0"}`,
+	}
+
+	target := newFirewallStatesTestTarget(t, states, false, utils.FirewallStatesDetailOptions{TopN: 1, PageSize: 2})
+
+	samples := collectTestSamples(t, NewFirewallStatesDetailCollector(), target)
+
+	// 5 states in pages of 2 should take 3 pages
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_scanned_count", nil); !ok || sample.value != 5 {
+		t.Errorf("Expected 5 scanned states, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_pages_count", nil); !ok || sample.value != 3 {
+		t.Errorf("Expected 3 pages, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_truncated", nil); !ok || sample.value != 0 {
+		t.Errorf("Expected the state table not to be truncated, got %v", sample.value)
+	}
+
+	// Verify the breakdown by interface, protocol and direction
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_count", map[string]string{"interface": "wan", "protocol": "tcp", "direction": "in"}); !ok || sample.value != 3 {
+		t.Errorf("Expected 3 wan/tcp/in states, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_tcp_count", map[string]string{"state": "ESTABLISHED:ESTABLISHED"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 established TCP states, got %v", sample.value)
+	}
+	if count := countTestSamples(samples, "pfsense_firewall_states_detail_tcp_count"); count != 2 {
+		t.Errorf("Expected 2 TCP states, got %d", count)
+	}
+
+	// Verify only the top source and destination are reported
+	if count := countTestSamples(samples, "pfsense_firewall_states_detail_top_source_count"); count != 1 {
+		t.Errorf("Expected 1 top source, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_top_source_count", map[string]string{"address": "203.0.113.5"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 203.0.113.5 to be the top source with 2 states, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_top_destination_count", map[string]string{"address": "198.51.100.1"}); !ok || sample.value != 3 {
+		t.Errorf("Expected 198.51.100.1 to be the top destination with 3 states, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_firewall_states_detail_unique_addresses_count", map[string]string{"direction": "source"}); !ok || sample.value != 4 {
+		t.Errorf("Expected 4 unique source addresses, got %v", sample.value)
+	}
+}
+
+func TestFirewallStatesDetailCollectorCollectWithTargetError(t *testing.T) {
+	// Test with a target that doesn't support the states endpoint
+	target := newTestTarget(t, map[string]string{})
+
+	samples := collectTestSamples(t, NewFirewallStatesDetailCollector(), target)
+
+	if len(samples) != 0 {
+		t.Errorf("Expected no metrics on error, got %d", len(samples))
+	}
+}
+
+func TestPageFirewallStatesRepeatedPage(t *testing.T) {
+	states := []string{
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.5:40000","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}`,
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.6:40000","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}`,
+		`{"interface":"wan","protocol":"tcp","direction":"in","source":"203.0.113.7:40000","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}`,
+	}
+
+	// An API ignoring the offset would otherwise be paged forever
+	target := newFirewallStatesTestTarget(t, states, true, utils.FirewallStatesDetailOptions{})
+	count := 0
+	pages, truncated, err := pageFirewallStates(target, 2, 1000, func(FirewallStateEntry) { count++ })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pages != 2 || count != 2 {
+		t.Errorf("Expected to stop at the repeated second page after 2 states, got %d pages and %d states", pages, count)
+	}
+	if !truncated {
+		t.Errorf("Expected paging to be reported as truncated")
+	}
+}
+
+func TestPageFirewallStatesMaxStates(t *testing.T) {
+	states := make([]string, 10)
+	for idx := range states {
+		states[idx] = fmt.Sprintf(`{"interface":"wan","protocol":"udp","direction":"in","source":"203.0.113.%d:5000","destination":"198.51.100.1:53","state":"SINGLE:NO_TRAFFIC"}`, idx)
+	}
+
+	// Paging should stop once the maximum number of states has been scanned
+	target := newFirewallStatesTestTarget(t, states, false, utils.FirewallStatesDetailOptions{})
+	count := 0
+	pages, truncated, err := pageFirewallStates(target, 2, 4, func(FirewallStateEntry) { count++ })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pages != 2 || count != 4 {
+		t.Errorf("Expected 2 pages and 4 states, got %d pages and %d states", pages, count)
+	}
+	if !truncated {
+		t.Errorf("Expected paging to be reported as truncated")
+	}
+}
+
+func TestFirewallStateAddress(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"192.168.1.1:443", "192.168.1.1"},
+		{"192.168.1.1", "192.168.1.1"},
+		{"2001:db8::1[443]", "2001:db8::1"},
+		{"[2001:db8::1]:443", "2001:db8::1"},
+		{"2001:db8::1", "2001:db8::1"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			if result := firewallStateAddress(tt.endpoint); result != tt.expected {
+				t.Errorf("Expected '%s' for '%s', got '%s'", tt.expected, tt.endpoint, result)
+			}
+		})
+	}
+}
+
+func TestFirewallStateEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		address  string
+		port     string
+	}{
+		{"192.168.1.1:443", "192.168.1.1", "443"},
+		{"192.168.1.10:80 (203.0.113.1:8080)", "192.168.1.10", "80"},
+		{"2001:db8::1[443]", "2001:db8::1", "443"},
+		{"[2001:db8::1]:443", "2001:db8::1", "443"},
+		{"192.168.1.1", "192.168.1.1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			address, port := firewallStateEndpoint(tt.endpoint)
+			if address != tt.address || port != tt.port {
+				t.Errorf("Expected '%s' and '%s' for '%s', got '%s' and '%s'", tt.address, tt.port, tt.endpoint, address, port)
+			}
+		})
+	}
+}
+
+func TestFirewallStatesAddressesTop(t *testing.T) {
+	addresses := newFirewallStatesAddresses(10)
+	for address, count := range map[string]int{"10.0.0.3": 1, "10.0.0.2": 5, "10.0.0.1": 5} {
+		for range count {
+			addresses.Add(address)
+		}
+	}
+
+	top := addresses.Top(2)
+
+	// Ties should be ordered by address
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 addresses, got %d", len(top))
+	}
+	if top[0].address != "10.0.0.1" || top[0].count != 5 || top[1].address != "10.0.0.2" {
+		t.Errorf("Expected [10.0.0.1 10.0.0.2] with 5 states, got %v", top)
+	}
+
+	// Requesting more than available should return all addresses
+	if top := addresses.Top(10); len(top) != 3 {
+		t.Errorf("Expected 3 addresses, got %d", len(top))
+	}
+}
+
+func TestFirewallStatesAddressesBounded(t *testing.T) {
+	addresses := newFirewallStatesAddresses(3)
+
+	// Flood with unique addresses before a heavy hitter appears
+	for idx := range 100 {
+		addresses.Add(fmt.Sprintf("203.0.113.%d", idx))
+	}
+	for range 50 {
+		addresses.Add("198.51.100.1")
+	}
+
+	// Only the maximum number of addresses should be tracked
+	if addresses.Len() != 3 {
+		t.Errorf("Expected 3 tracked addresses, got %d", addresses.Len())
+	}
+
+	// The heavy hitter should still be the top address despite appearing late
+	top := addresses.Top(1)
+	if len(top) != 1 || top[0].address != "198.51.100.1" || top[0].count < 50 {
+		t.Errorf("Expected 198.51.100.1 to be the top address with at least 50 states, got %v", top)
+	}
+}
@@ -20,6 +20,13 @@ type GatewayCollector struct {
 	gatewayDelaySeconds  *prometheus.GaugeVec
 	gatewayStdDevSeconds *prometheus.GaugeVec
 	gatewayUp            *prometheus.GaugeVec
+
+	gatewayLatencyLowThresholdSeconds  *prometheus.GaugeVec
+	gatewayLatencyHighThresholdSeconds *prometheus.GaugeVec
+	gatewayLossLowThresholdRatio       *prometheus.GaugeVec
+	gatewayLossHighThresholdRatio      *prometheus.GaugeVec
+	gatewayProbeIntervalSeconds        *prometheus.GaugeVec
+	gatewayMonitoringDisabled          *prometheus.GaugeVec
 }
 
 // GatewayStats represents the structure of the gateway status data returned by the API.
@@ -34,6 +41,27 @@ type GatewayStats struct {
 	MonitorIP string  `json:"monitorip"`
 }
 
+// GatewayConfig represents the structure of the gateway configuration data returned by the API. Thresholds
+// are nil when the gateway uses pfSense's default values.
+type GatewayConfig struct {
+	Name           string   `json:"name"`
+	LatencyLow     *float64 `json:"latencylow"`
+	LatencyHigh    *float64 `json:"latencyhigh"`
+	LossLow        *float64 `json:"losslow"`
+	LossHigh       *float64 `json:"losshigh"`
+	Interval       *float64 `json:"interval"`
+	MonitorDisable bool     `json:"monitor_disable"`
+}
+
+// Default gateway monitoring thresholds used by pfSense when none are configured.
+const (
+	gatewayDefaultLatencyLowMs  = 200
+	gatewayDefaultLatencyHighMs = 500
+	gatewayDefaultLossLow       = 10
+	gatewayDefaultLossHigh      = 20
+	gatewayDefaultIntervalMs    = 500
+)
+
 // NewGatewayCollector is the constructor
 func NewGatewayCollector() *GatewayCollector {
 	return &GatewayCollector{
@@ -65,6 +93,48 @@ func NewGatewayCollector() *GatewayCollector {
 			},
 			[]string{"host", "name", "srcip", "monitorip", "substatus"},
 		),
+		gatewayLatencyLowThresholdSeconds: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_latency_low_threshold_seconds",
+				Help: "The configured latency threshold in seconds that raises a gateway delay alarm.",
+			},
+			[]string{"host", "name"},
+		),
+		gatewayLatencyHighThresholdSeconds: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_latency_high_threshold_seconds",
+				Help: "The configured latency threshold in seconds that marks the gateway as down.",
+			},
+			[]string{"host", "name"},
+		),
+		gatewayLossLowThresholdRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_loss_low_threshold_ratio",
+				Help: "The configured packet loss threshold as a decimal percentage (0.0 - 1.0) that raises a gateway loss alarm.",
+			},
+			[]string{"host", "name"},
+		),
+		gatewayLossHighThresholdRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_loss_high_threshold_ratio",
+				Help: "The configured packet loss threshold as a decimal percentage (0.0 - 1.0) that marks the gateway as down.",
+			},
+			[]string{"host", "name"},
+		),
+		gatewayProbeIntervalSeconds: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_probe_interval_seconds",
+				Help: "The configured interval between gateway monitoring probes in seconds.",
+			},
+			[]string{"host", "name"},
+		),
+		gatewayMonitoringDisabled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_monitoring_disabled",
+				Help: "Whether gateway monitoring is disabled (1) or enabled (0).",
+			},
+			[]string{"host", "name"},
+		),
 	}
 }
 
@@ -79,6 +149,12 @@ func (c *GatewayCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.gatewayDelaySeconds.Describe(ch)
 	c.gatewayStdDevSeconds.Describe(ch)
 	c.gatewayUp.Describe(ch)
+	c.gatewayLatencyLowThresholdSeconds.Describe(ch)
+	c.gatewayLatencyHighThresholdSeconds.Describe(ch)
+	c.gatewayLossLowThresholdRatio.Describe(ch)
+	c.gatewayLossHighThresholdRatio.Describe(ch)
+	c.gatewayProbeIntervalSeconds.Describe(ch)
+	c.gatewayMonitoringDisabled.Describe(ch)
 }
 
 // Collect fetches the stats and sends them to the channel.
@@ -113,11 +189,33 @@ func (c *GatewayCollector) CollectWithTarget(ch chan<- prometheus.Metric, target
 		c.gatewayUp.WithLabelValues(target.Host, gw.Name, gw.SourceIP, gw.MonitorIP, gw.Substatus).Set(float64(gatewayUpToFloat64(gw.Status)))
 	}
 
+	// Collect the configured thresholds, but don't discard the live metrics if they can't be fetched
+	var configs []GatewayConfig
+	if err := utils.RequestData(target, "GET", "/api/v2/routing/gateways", &configs); err != nil {
+		log.Error("gateways", "failed to fetch gateway configurations from host %s: %s", target.Host, err.Error())
+	}
+
+	// Extract threshold metrics for each gateway configuration identified
+	for _, cfg := range configs {
+		c.gatewayLatencyLowThresholdSeconds.WithLabelValues(target.Host, cfg.Name).Set(gatewayConfigValue(cfg.LatencyLow, gatewayDefaultLatencyLowMs) / 1000.0) // Convert milliseconds to seconds
+		c.gatewayLatencyHighThresholdSeconds.WithLabelValues(target.Host, cfg.Name).Set(gatewayConfigValue(cfg.LatencyHigh, gatewayDefaultLatencyHighMs) / 1000.0)
+		c.gatewayLossLowThresholdRatio.WithLabelValues(target.Host, cfg.Name).Set(gatewayConfigValue(cfg.LossLow, gatewayDefaultLossLow) / 100.0) // Convert percentage to ratio
+		c.gatewayLossHighThresholdRatio.WithLabelValues(target.Host, cfg.Name).Set(gatewayConfigValue(cfg.LossHigh, gatewayDefaultLossHigh) / 100.0)
+		c.gatewayProbeIntervalSeconds.WithLabelValues(target.Host, cfg.Name).Set(gatewayConfigValue(cfg.Interval, gatewayDefaultIntervalMs) / 1000.0)
+		c.gatewayMonitoringDisabled.WithLabelValues(target.Host, cfg.Name).Set(utils.BoolToFloat64(cfg.MonitorDisable))
+	}
+
 	// Collect the metrics
 	c.gatewayLossRatio.Collect(ch)
 	c.gatewayDelaySeconds.Collect(ch)
 	c.gatewayStdDevSeconds.Collect(ch)
 	c.gatewayUp.Collect(ch)
+	c.gatewayLatencyLowThresholdSeconds.Collect(ch)
+	c.gatewayLatencyHighThresholdSeconds.Collect(ch)
+	c.gatewayLossLowThresholdRatio.Collect(ch)
+	c.gatewayLossHighThresholdRatio.Collect(ch)
+	c.gatewayProbeIntervalSeconds.Collect(ch)
+	c.gatewayMonitoringDisabled.Collect(ch)
 }
 
 // resetMetrics resets all metrics in the collector.
@@ -126,6 +224,12 @@ func (c *GatewayCollector) resetMetrics() {
 	c.gatewayDelaySeconds.Reset()
 	c.gatewayStdDevSeconds.Reset()
 	c.gatewayUp.Reset()
+	c.gatewayLatencyLowThresholdSeconds.Reset()
+	c.gatewayLatencyHighThresholdSeconds.Reset()
+	c.gatewayLossLowThresholdRatio.Reset()
+	c.gatewayLossHighThresholdRatio.Reset()
+	c.gatewayProbeIntervalSeconds.Reset()
+	c.gatewayMonitoringDisabled.Reset()
 }
 
 // gatewayUpToFloat64 converts the gateway status string to a float64 for Prometheus metrics.
@@ -135,3 +239,11 @@ func gatewayUpToFloat64(status string) float64 {
 	}
 	return 0.0
 }
+
+// gatewayConfigValue returns the configured value of a gateway setting, or the default if it isn't set.
+func gatewayConfigValue(value *float64, defaultValue float64) float64 {
+	if value == nil || *value == 0 {
+		return defaultValue
+	}
+	return *value
+}
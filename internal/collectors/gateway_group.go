@@ -0,0 +1,213 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewGatewayGroupCollector())
+}
+
+// GatewayGroupCollector collects metrics about gateway group membership and failover status.
+type GatewayGroupCollector struct {
+	gatewayGroupInfo         *prometheus.GaugeVec
+	gatewayGroupMemberInfo   *prometheus.GaugeVec
+	gatewayGroupMemberActive *prometheus.GaugeVec
+	gatewayGroupActiveTier   *prometheus.GaugeVec
+	gatewayGroupFailedOver   *prometheus.GaugeVec
+	gatewayGroupUp           *prometheus.GaugeVec
+}
+
+// GatewayGroupStats represents the structure of the gateway group data returned by the API.
+type GatewayGroupStats struct {
+	Name       string                      `json:"name"`
+	Trigger    string                      `json:"trigger"`
+	Descr      string                      `json:"descr"`
+	Priorities []GatewayGroupPriorityStats `json:"priorities"`
+}
+
+// GatewayGroupPriorityStats represents the structure of a gateway group member returned by the API.
+type GatewayGroupPriorityStats struct {
+	Gateway   string `json:"gateway"`
+	Tier      int64  `json:"tier"`
+	VirtualIP string `json:"virtual_ip"`
+}
+
+// NewGatewayGroupCollector is the constructor
+func NewGatewayGroupCollector() *GatewayGroupCollector {
+	return &GatewayGroupCollector{
+		gatewayGroupInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_info",
+				Help: "Contains details about the gateway group's trigger level.",
+			},
+			[]string{"host", "name", "trigger", "descr"},
+		),
+		gatewayGroupMemberInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_member_info",
+				Help: "Contains details about the gateway group's members and their tiers.",
+			},
+			[]string{"host", "group", "gateway", "tier", "virtual_ip"},
+		),
+		gatewayGroupMemberActive: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_member_active",
+				Help: "Whether the gateway is currently an active member of the group (1) or not (0).",
+			},
+			[]string{"host", "group", "gateway", "tier"},
+		),
+		gatewayGroupActiveTier: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_active_tier",
+				Help: "The tier currently in use by the gateway group (-1 = no usable members).",
+			},
+			[]string{"host", "group"},
+		),
+		gatewayGroupFailedOver: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_failed_over",
+				Help: "Whether the gateway group has failed over to a lower priority tier (1) or not (0).",
+			},
+			[]string{"host", "group"},
+		),
+		gatewayGroupUp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "gateway_group_up",
+				Help: "Whether the gateway group has at least one usable member (1) or not (0).",
+			},
+			[]string{"host", "group"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *GatewayGroupCollector) Name() string {
+	return "gateway_groups"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *GatewayGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.gatewayGroupInfo.Describe(ch)
+	c.gatewayGroupMemberInfo.Describe(ch)
+	c.gatewayGroupMemberActive.Describe(ch)
+	c.gatewayGroupActiveTier.Describe(ch)
+	c.gatewayGroupFailedOver.Describe(ch)
+	c.gatewayGroupUp.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *GatewayGroupCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the configured gateway groups from the target
+	var groups []GatewayGroupStats
+	if err := utils.RequestData(target, "GET", "/api/v2/routing/gateway/groups", &groups); err != nil {
+		log.Error("gateway_groups", "failed to fetch gateway groups from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Collect the live gateway statuses to determine which members are usable
+	var gateways []GatewayStats
+	if err := utils.RequestData(target, "GET", "/api/v2/status/gateways", &gateways); err != nil {
+		log.Error("gateway_groups", "failed to fetch gateway statuses from host %s: %s", target.Host, err.Error())
+		return
+	}
+	statuses := make(map[string]GatewayStats, len(gateways))
+	for _, gw := range gateways {
+		statuses[gw.Name] = gw
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each gateway group identified
+	for _, group := range groups {
+		c.gatewayGroupInfo.WithLabelValues(target.Host, group.Name, group.Trigger, group.Descr).Set(1)
+
+		// Determine the tier the group is currently using
+		activeTier := gatewayGroupActiveTier(group, statuses)
+		c.gatewayGroupActiveTier.WithLabelValues(target.Host, group.Name).Set(float64(activeTier))
+		c.gatewayGroupUp.WithLabelValues(target.Host, group.Name).Set(utils.BoolToFloat64(activeTier != -1))
+		c.gatewayGroupFailedOver.WithLabelValues(target.Host, group.Name).Set(
+			utils.BoolToFloat64(activeTier != -1 && activeTier > gatewayGroupLowestTier(group)),
+		)
+
+		// Members are active when they are usable and belong to the tier in use
+		for _, member := range group.Priorities {
+			tier := fmt.Sprintf("%d", member.Tier)
+			active := member.Tier == activeTier && gatewayGroupMemberUsable(statuses[member.Gateway], group.Trigger)
+			c.gatewayGroupMemberInfo.WithLabelValues(target.Host, group.Name, member.Gateway, tier, member.VirtualIP).Set(1)
+			c.gatewayGroupMemberActive.WithLabelValues(target.Host, group.Name, member.Gateway, tier).Set(utils.BoolToFloat64(active))
+		}
+	}
+
+	// Collect the metrics
+	c.gatewayGroupInfo.Collect(ch)
+	c.gatewayGroupMemberInfo.Collect(ch)
+	c.gatewayGroupMemberActive.Collect(ch)
+	c.gatewayGroupActiveTier.Collect(ch)
+	c.gatewayGroupFailedOver.Collect(ch)
+	c.gatewayGroupUp.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *GatewayGroupCollector) resetMetrics() {
+	c.gatewayGroupInfo.Reset()
+	c.gatewayGroupMemberInfo.Reset()
+	c.gatewayGroupMemberActive.Reset()
+	c.gatewayGroupActiveTier.Reset()
+	c.gatewayGroupFailedOver.Reset()
+	c.gatewayGroupUp.Reset()
+}
+
+// gatewayGroupActiveTier returns the lowest tier of the group with at least one usable member, or -1 if none are usable.
+func gatewayGroupActiveTier(group GatewayGroupStats, statuses map[string]GatewayStats) int64 {
+	activeTier := int64(-1)
+	for _, member := range group.Priorities {
+		if !gatewayGroupMemberUsable(statuses[member.Gateway], group.Trigger) {
+			continue
+		}
+		if activeTier == -1 || member.Tier < activeTier {
+			activeTier = member.Tier
+		}
+	}
+	return activeTier
+}
+
+// gatewayGroupLowestTier returns the lowest (highest priority) tier configured on the group.
+func gatewayGroupLowestTier(group GatewayGroupStats) int64 {
+	lowestTier := int64(-1)
+	for _, member := range group.Priorities {
+		if lowestTier == -1 || member.Tier < lowestTier {
+			lowestTier = member.Tier
+		}
+	}
+	return lowestTier
+}
+
+// gatewayGroupMemberUsable determines whether a gateway can be used by a group with the given trigger level.
+// This mirrors pfSense's own logic, where the trigger determines which alarms take a member out of service.
+// Gateways exceeding their high loss or delay thresholds are already reported as down, while gateways exceeding
+// only their low (alert) thresholds stay online with a 'loss' or 'delay' substatus.
+func gatewayGroupMemberUsable(gw GatewayStats, trigger string) bool {
+	// Gateways that are down (or unknown) are never usable
+	if gatewayUpToFloat64(gw.Status) == 0 || gw.Substatus == "down" || gw.Substatus == "force_down" {
+		return false
+	}
+
+	switch trigger {
+	case "downloss":
+		return gw.Substatus != "loss"
+	case "downlatency":
+		return gw.Substatus != "delay"
+	case "downlosslatency":
+		return gw.Substatus != "loss" && gw.Substatus != "delay"
+	default:
+		return true
+	}
+}
@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestGatewayGroupCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/routing/gateway/groups": `[{"name":"WAN_FAILOVER","trigger":"downloss","descr":"Multi-WAN","priorities":[
+			{"gateway":"WAN_DHCP","tier":1,"virtual_ip":"address"},
+			{"gateway":"WAN2_DHCP","tier":2,"virtual_ip":"address"}
+		]}]`,
+		"/api/v2/status/gateways": `[
+			{"name":"WAN_DHCP","status":"online","substatus":"loss"},
+			{"name":"WAN2_DHCP","status":"online","substatus":"none"}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewGatewayGroupCollector(), target)
+
+	// The primary tier exceeds its low loss threshold, so a downloss group should have failed over to tier 2
+	if sample, ok := findTestSample(samples, "pfsense_gateway_group_active_tier", map[string]string{"group": "WAN_FAILOVER"}); !ok || sample.value != 2 {
+		t.Errorf("Expected active tier 2, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_gateway_group_failed_over", map[string]string{"group": "WAN_FAILOVER"}); !ok || sample.value != 1 {
+		t.Errorf("Expected group to have failed over, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_gateway_group_member_active", map[string]string{"gateway": "WAN2_DHCP"}); !ok || sample.value != 1 {
+		t.Errorf("Expected WAN2_DHCP to be active, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_gateway_group_member_active", map[string]string{"gateway": "WAN_DHCP"}); !ok || sample.value != 0 {
+		t.Errorf("Expected WAN_DHCP to be inactive, got %v", sample.value)
+	}
+	if count := countTestSamples(samples, "pfsense_gateway_group_member_info"); count != 2 {
+		t.Errorf("Expected 2 member info metrics, got %d", count)
+	}
+}
+
+func TestGatewayGroupActiveTier(t *testing.T) {
+	group := GatewayGroupStats{
+		Name:    "test",
+		Trigger: "down",
+		Priorities: []GatewayGroupPriorityStats{
+			{Gateway: "GW1", Tier: 1},
+			{Gateway: "GW2", Tier: 1},
+			{Gateway: "GW3", Tier: 3},
+		},
+	}
+
+	// All members online should use the first tier
+	statuses := map[string]GatewayStats{
+		"GW1": {Name: "GW1", Status: "online"},
+		"GW2": {Name: "GW2", Status: "online"},
+		"GW3": {Name: "GW3", Status: "online"},
+	}
+	if tier := gatewayGroupActiveTier(group, statuses); tier != 1 {
+		t.Errorf("Expected active tier 1, got %d", tier)
+	}
+
+	// One member down in the first tier should not fail over
+	statuses["GW1"] = GatewayStats{Name: "GW1", Status: "down", Substatus: "none"}
+	if tier := gatewayGroupActiveTier(group, statuses); tier != 1 {
+		t.Errorf("Expected active tier 1, got %d", tier)
+	}
+
+	// All members down in the first tier should fail over
+	statuses["GW2"] = GatewayStats{Name: "GW2", Status: "down", Substatus: "highloss"}
+	if tier := gatewayGroupActiveTier(group, statuses); tier != 3 {
+		t.Errorf("Expected active tier 3, got %d", tier)
+	}
+
+	// No usable members should return -1
+	delete(statuses, "GW3")
+	if tier := gatewayGroupActiveTier(group, statuses); tier != -1 {
+		t.Errorf("Expected active tier -1, got %d", tier)
+	}
+
+	// The lowest tier should not depend on gateway status
+	if tier := gatewayGroupLowestTier(group); tier != 1 {
+		t.Errorf("Expected lowest tier 1, got %d", tier)
+	}
+}
+
+func TestGatewayGroupMemberUsable(t *testing.T) {
+	tests := []struct {
+		substatus string
+		status    string
+		trigger   string
+		expected  bool
+	}{
+		{"none", "online", "down", true},
+		{"none", "online", "downlosslatency", true},
+		{"loss", "online", "down", true},
+		{"loss", "online", "downloss", false},
+		{"loss", "online", "downlatency", true},
+		{"loss", "online", "downlosslatency", false},
+		{"delay", "online", "down", true},
+		{"delay", "online", "downloss", true},
+		{"delay", "online", "downlatency", false},
+		{"delay", "online", "downlosslatency", false},
+		{"highloss", "down", "down", false},
+		{"highdelay", "down", "downloss", false},
+		{"force_down", "down", "down", false},
+		{"none", "down", "down", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.trigger+"_"+tt.status+"_"+tt.substatus, func(t *testing.T) {
+			gw := GatewayStats{Name: "GW", Status: tt.status, Substatus: tt.substatus}
+			if result := gatewayGroupMemberUsable(gw, tt.trigger); result != tt.expected {
+				t.Errorf("Expected %t for substatus '%s' with trigger '%s', got %t", tt.expected, tt.substatus, tt.trigger, result)
+			}
+		})
+	}
+}
@@ -29,6 +29,24 @@ func TestNewGatewayCollector(t *testing.T) {
 	if collector.gatewayUp == nil {
 		t.Error("Expected gatewayUp metric to be initialized")
 	}
+	if collector.gatewayLatencyLowThresholdSeconds == nil {
+		t.Error("Expected gatewayLatencyLowThresholdSeconds metric to be initialized")
+	}
+	if collector.gatewayLatencyHighThresholdSeconds == nil {
+		t.Error("Expected gatewayLatencyHighThresholdSeconds metric to be initialized")
+	}
+	if collector.gatewayLossLowThresholdRatio == nil {
+		t.Error("Expected gatewayLossLowThresholdRatio metric to be initialized")
+	}
+	if collector.gatewayLossHighThresholdRatio == nil {
+		t.Error("Expected gatewayLossHighThresholdRatio metric to be initialized")
+	}
+	if collector.gatewayProbeIntervalSeconds == nil {
+		t.Error("Expected gatewayProbeIntervalSeconds metric to be initialized")
+	}
+	if collector.gatewayMonitoringDisabled == nil {
+		t.Error("Expected gatewayMonitoringDisabled metric to be initialized")
+	}
 }
 
 func TestGatewayCollectorName(t *testing.T) {
@@ -54,9 +72,9 @@ func TestGatewayCollectorDescribe(t *testing.T) {
 		count++
 	}
 
-	// Should have 4 descriptions
-	if count != 4 {
-		t.Errorf("Expected 4 metric descriptions, got %d", count)
+	// Should have 10 descriptions
+	if count != 10 {
+		t.Errorf("Expected 10 metric descriptions, got %d", count)
 	}
 }
 
@@ -114,6 +132,53 @@ func TestGatewayCollectorCollectWithTarget(t *testing.T) {
 	_ = server.URL // Use server URL to avoid unused variable warning
 }
 
+func TestGatewayCollectorCollectThresholds(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/status/gateways": `[{"name":"WAN_DHCP","loss":0,"delay":15.3,"stddev":3.2,"status":"online","substatus":"none"}]`,
+		"/api/v2/routing/gateways": `[
+			{"name":"WAN_DHCP","latencylow":100,"latencyhigh":300,"losslow":5,"losshigh":15,"interval":1000,"monitor_disable":false},
+			{"name":"WAN2_DHCP","latencylow":null,"latencyhigh":null,"losslow":null,"losshigh":null,"interval":null,"monitor_disable":true}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewGatewayCollector(), target)
+
+	tests := []struct {
+		name     string
+		gateway  string
+		expected float64
+	}{
+		{"pfsense_gateway_latency_low_threshold_seconds", "WAN_DHCP", 0.1},
+		{"pfsense_gateway_latency_high_threshold_seconds", "WAN_DHCP", 0.3},
+		{"pfsense_gateway_loss_low_threshold_ratio", "WAN_DHCP", 0.05},
+		{"pfsense_gateway_loss_high_threshold_ratio", "WAN_DHCP", 0.15},
+		{"pfsense_gateway_probe_interval_seconds", "WAN_DHCP", 1},
+		{"pfsense_gateway_monitoring_disabled", "WAN_DHCP", 0},
+		{"pfsense_gateway_latency_low_threshold_seconds", "WAN2_DHCP", 0.2},
+		{"pfsense_gateway_latency_high_threshold_seconds", "WAN2_DHCP", 0.5},
+		{"pfsense_gateway_loss_low_threshold_ratio", "WAN2_DHCP", 0.1},
+		{"pfsense_gateway_loss_high_threshold_ratio", "WAN2_DHCP", 0.2},
+		{"pfsense_gateway_probe_interval_seconds", "WAN2_DHCP", 0.5},
+		{"pfsense_gateway_monitoring_disabled", "WAN2_DHCP", 1},
+	}
+
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, map[string]string{"name": tt.gateway})
+		if !ok {
+			t.Errorf("Expected %s metric for %s", tt.name, tt.gateway)
+			continue
+		}
+		if sample.value != tt.expected {
+			t.Errorf("Expected %s for %s to be %f, got %f", tt.name, tt.gateway, tt.expected, sample.value)
+		}
+	}
+
+	// Live metrics should still be reported
+	if _, ok := findTestSample(samples, "pfsense_gateway_up", map[string]string{"name": "WAN_DHCP"}); !ok {
+		t.Error("Expected live gateway metrics to be reported")
+	}
+}
+
 func TestGatewayCollectorCollectWithTargetError(t *testing.T) {
 	// Test with unreachable target to trigger error handling
 	target := &utils.Target{
@@ -202,3 +267,18 @@ func TestGatewayStatsStruct(t *testing.T) {
 		t.Errorf("Expected MonitorIP '1.1.1.1', got %s", stats.MonitorIP)
 	}
 }
+
+func TestGatewayConfigValue(t *testing.T) {
+	value := 150.0
+	zero := 0.0
+
+	if result := gatewayConfigValue(&value, 200); result != 150 {
+		t.Errorf("Expected configured value 150, got %f", result)
+	}
+	if result := gatewayConfigValue(nil, 200); result != 200 {
+		t.Errorf("Expected default value 200 for nil, got %f", result)
+	}
+	if result := gatewayConfigValue(&zero, 200); result != 200 {
+		t.Errorf("Expected default value 200 for zero, got %f", result)
+	}
+}
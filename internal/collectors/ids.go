@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// idsEngines are the short names of the IDS/IPS packages supported by the collector.
+var idsEngines = []string{"suricata", "snort"}
+
+// idsBlockedTable is the pf table both Suricata and Snort add blocked hosts to.
+const idsBlockedTable = "snort2c"
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewIDSCollector())
+}
+
+// IDSCollector collects metrics about the Suricata and Snort IDS/IPS packages and the hosts they block.
+type IDSCollector struct {
+	idsEngineRunning *prometheus.GaugeVec
+	idsBlockedHosts  *prometheus.GaugeVec
+}
+
+// NewIDSCollector is the constructor
+func NewIDSCollector() *IDSCollector {
+	return &IDSCollector{
+		idsEngineRunning: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "ids_engine_running",
+				Help: "Whether all enabled instances of the IDS/IPS engine are running (1) or not (0).",
+			},
+			[]string{"host", "engine"},
+		),
+		idsBlockedHosts: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "ids_blocked_hosts_count",
+				Help: "The number of hosts currently blocked by the IDS/IPS in the snort2c table.",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *IDSCollector) Name() string {
+	return "ids"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *IDSCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.idsEngineRunning.Describe(ch)
+	c.idsBlockedHosts.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *IDSCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Skip targets without either IDS/IPS package installed
+	var installed []string
+	for _, engine := range idsEngines {
+		ok, err := installedPackages.Installed(target, engine)
+		if err != nil {
+			log.Error("ids", "failed to fetch installed packages from host %s: %s", target.Host, err.Error())
+			return
+		}
+		if ok {
+			installed = append(installed, engine)
+		}
+	}
+	if len(installed) == 0 {
+		log.Debug("ids", "skipping host %s without the Suricata or Snort package installed", target.Host)
+		return
+	}
+
+	// Collect the service statuses of the installed engines from the target
+	var services []ServiceStats
+	if err := utils.RequestData(target, "GET", "/api/v2/status/services", &services); err != nil {
+		log.Error("ids", "failed to fetch service statuses from host %s: %s", target.Host, err.Error())
+	}
+
+	// Collect the hosts blocked by either engine from the target
+	blocked, err := fetchTableEntries(target, idsBlockedTable)
+	if err != nil {
+		log.Error("ids", "failed to fetch %s table from host %s: %s", idsBlockedTable, target.Host, err.Error())
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Update the metrics, only reporting engines with an enabled service
+	for _, engine := range installed {
+		found, running := false, true
+		for _, service := range services {
+			if service.Name == engine && service.Enabled {
+				found = true
+				running = running && service.Status
+			}
+		}
+		if found {
+			c.idsEngineRunning.WithLabelValues(target.Host, engine).Set(utils.BoolToFloat64(running))
+		}
+	}
+	if err == nil {
+		c.idsBlockedHosts.WithLabelValues(target.Host).Set(float64(len(blocked)))
+	}
+
+	// Collect the metrics
+	c.idsEngineRunning.Collect(ch)
+	c.idsBlockedHosts.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *IDSCollector) resetMetrics() {
+	c.idsEngineRunning.Reset()
+	c.idsBlockedHosts.Reset()
+}
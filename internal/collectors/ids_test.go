@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestIDSCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages":   `[{"name":"pfSense-pkg-suricata","shortname":"suricata"}]`,
+		"/api/v2/status/services":   `[{"name":"suricata","enabled":true,"status":true},{"name":"snort","enabled":true,"status":false}]`,
+		"/api/v2/diagnostics/table": `{"id":"snort2c","entries":["203.0.113.5","203.0.113.6"]}`,
+	})
+
+	samples := collectTestSamples(t, NewIDSCollector(), target)
+	if sample, ok := findTestSample(samples, "pfsense_ids_blocked_hosts_count", nil); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 blocked hosts, got %v (found: %v)", sample.value, ok)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_ids_engine_running", map[string]string{"engine": "suricata"}); !ok || sample.value != 1 {
+		t.Errorf("Expected suricata to be running, got %v (found: %v)", sample.value, ok)
+	}
+
+	// Engines that aren't installed should not be reported
+	if _, ok := findTestSample(samples, "pfsense_ids_engine_running", map[string]string{"engine": "snort"}); ok {
+		t.Error("Expected no status for snort without the package installed")
+	}
+}
+
+func TestIDSCollectorCollectWithTargetEngineStopped(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[{"name":"pfSense-pkg-suricata","shortname":"suricata"},{"name":"pfSense-pkg-snort","shortname":"snort"}]`,
+		"/api/v2/status/services": `[
+			{"name":"suricata","enabled":true,"status":true},
+			{"name":"suricata","enabled":true,"status":false},
+			{"name":"snort","enabled":false,"status":false}
+		]`,
+		"/api/v2/diagnostics/table": `{"id":"snort2c","entries":[]}`,
+	})
+
+	// An engine is only running if all of its enabled services are, and engines without an enabled service are omitted
+	samples := collectTestSamples(t, NewIDSCollector(), target)
+	if sample, ok := findTestSample(samples, "pfsense_ids_engine_running", map[string]string{"engine": "suricata"}); !ok || sample.value != 0 {
+		t.Errorf("Expected suricata not to be running, got %v (found: %v)", sample.value, ok)
+	}
+	if _, ok := findTestSample(samples, "pfsense_ids_engine_running", map[string]string{"engine": "snort"}); ok {
+		t.Error("Expected no status for snort without an enabled service")
+	}
+}
+
+func TestIDSCollectorCollectWithTargetNotInstalled(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[]`,
+	})
+
+	// Targets without either package should be skipped without metrics
+	samples := collectTestSamples(t, NewIDSCollector(), target)
+	if len(samples) != 0 {
+		t.Errorf("Expected no metrics without the package installed, got %d", len(samples))
+	}
+}
+
+func TestIDSCollectorCollectWithTargetTableError(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[{"name":"pfSense-pkg-snort","shortname":"snort"}]`,
+		"/api/v2/status/services": `[{"name":"snort","enabled":true,"status":true}]`,
+	})
+
+	// The blocked hosts count should be omitted rather than reported as zero when the table can't be fetched
+	samples := collectTestSamples(t, NewIDSCollector(), target)
+	if count := countTestSamples(samples, "pfsense_ids_blocked_hosts_count"); count != 0 {
+		t.Errorf("Expected no blocked hosts count when the table can't be fetched, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_ids_engine_running", map[string]string{"engine": "snort"}); !ok || sample.value != 1 {
+		t.Errorf("Expected snort to be running, got %v (found: %v)", sample.value, ok)
+	}
+}
@@ -2,6 +2,9 @@ package collectors
 
 import (
 	"encoding/json"
+	"regexp"
+	"strconv"
+	"sync"
 
 	"github.com/pfrest/pfsense_exporter/internal/log"
 	"github.com/pfrest/pfsense_exporter/internal/registry"
@@ -28,6 +31,27 @@ type InterfaceCollector struct {
 	interfaceInPktsPassCount   *prometheus.GaugeVec
 	interfaceOutPktsCount      *prometheus.GaugeVec
 	interfaceOutPktsPassCount  *prometheus.GaugeVec
+	interfaceSpeedBytes        *prometheus.GaugeVec
+	interfaceMTUBytes          *prometheus.GaugeVec
+	interfaceInfo              *prometheus.GaugeVec
+
+	// interfaceStatusChanges is emitted as a const metric since its value must persist between scrapes.
+	interfaceStatusChanges *prometheus.Desc
+
+	// interfaceCounters are emitted as const metrics so cumulative statistics are exposed with the counter type.
+	interfaceCounters []statCounter[InterfaceStats]
+	counters          *counterTracker
+
+	// statuses tracks the last observed status of each interface, keyed by host and interface name.
+	statuses   map[string]map[string]*interfaceStatusState
+	statusesMu sync.Mutex
+}
+
+// interfaceStatusState holds the last observed status of an interface and how often it has changed.
+type interfaceStatusState struct {
+	status  string
+	changes float64
+	seen    bool
 }
 
 // InterfaceStats represents the structure of the interface status data returned by the API.
@@ -47,10 +71,23 @@ type InterfaceStats struct {
 	InPktsPass   float64 `json:"inpktspass"`
 	OutPkts      float64 `json:"outpkts"`
 	OutPktsPass  float64 `json:"outpktspass"`
+	Enable       bool    `json:"enable"`
+	MACAddr      string  `json:"macaddr"`
+	MTU          float64 `json:"mtu"`
+	Media        string  `json:"media"`
+	IPAddr       string  `json:"ipaddr"`
+	IPAddrV6     string  `json:"ipaddrv6"`
+	Gateway      string  `json:"gateway"`
+	GatewayV6    string  `json:"gatewayv6"`
 }
 
+// interfaceMediaSpeedRegex extracts the link speed from a media string such as "1000baseT <full-duplex>" or "10Gbase-SR".
+var interfaceMediaSpeedRegex = regexp.MustCompile(`(?i)(\d+)(G?)base`)
+
 // NewInterfaceCollector is the constructor
 func NewInterfaceCollector() *InterfaceCollector {
+	counterLabels := []string{"host", "name", "descr", "hwif"}
+
 	return &InterfaceCollector{
 		interfaceUp: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -62,80 +99,122 @@ func NewInterfaceCollector() *InterfaceCollector {
 		interfaceInErrsCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_in_errs_count",
-				Help: "The number of input errors on the interface.",
+				Help: "The number of input errors on the interface. Deprecated: use pfsense_interface_in_errs_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceOutErrsCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_out_errs_count",
-				Help: "The number of output errors on the interface.",
+				Help: "The number of output errors on the interface. Deprecated: use pfsense_interface_out_errs_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceCollisionsCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_collisions_count",
-				Help: "The number of collisions on the interface.",
+				Help: "The number of collisions on the interface. Deprecated: use pfsense_interface_collisions_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceInBytesCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_in_bytes",
-				Help: "The number of input bytes on the interface.",
+				Help: "The number of input bytes on the interface. Deprecated: use pfsense_interface_in_bytes_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceInBytesPassCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_in_pass_bytes",
-				Help: "The number of input bytes passed on the interface.",
+				Help: "The number of input bytes passed on the interface. Deprecated: use pfsense_interface_in_pass_bytes_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceOutBytesCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_out_bytes",
-				Help: "The number of output bytes on the interface.",
+				Help: "The number of output bytes on the interface. Deprecated: use pfsense_interface_out_bytes_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceOutBytesPassCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_out_pass_bytes",
-				Help: "The number of output bytes passed on the interface.",
+				Help: "The number of output bytes passed on the interface. Deprecated: use pfsense_interface_out_pass_bytes_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceInPktsCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_in_pkts_count",
-				Help: "The number of input packets handled by the interface.",
+				Help: "The number of input packets handled by the interface. Deprecated: use pfsense_interface_in_pkts_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceInPktsPassCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_in_pass_pkts_count",
-				Help: "The number of input packets passed on the interface.",
+				Help: "The number of input packets passed on the interface. Deprecated: use pfsense_interface_in_pass_pkts_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceOutPktsCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_out_pkts_count",
-				Help: "The number of output packets handled by the interface.",
+				Help: "The number of output packets handled by the interface. Deprecated: use pfsense_interface_out_pkts_total instead.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
 		interfaceOutPktsPassCount: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "interface_out_pass_pkts_count",
-				Help: "The number of output packets passed on the interface.",
+				Help: "The number of output packets passed on the interface. Deprecated: use pfsense_interface_out_pass_pkts_total instead.",
+			},
+			[]string{"host", "name", "descr", "hwif"},
+		),
+		interfaceSpeedBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_speed_bytes",
+				Help: "The negotiated link speed of the interface in bytes per second.",
 			},
 			[]string{"host", "name", "descr", "hwif"},
 		),
+		interfaceMTUBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_mtu_bytes",
+				Help: "The MTU of the interface in bytes.",
+			},
+			[]string{"host", "name", "descr", "hwif"},
+		),
+		interfaceInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_info",
+				Help: "Contains details about the interface's media, addressing and enable state.",
+			},
+			[]string{"host", "name", "descr", "hwif", "media", "macaddr", "ipaddr", "ipaddrv6", "gateway", "gatewayv6", "enable"},
+		),
+		interfaceStatusChanges: prometheus.NewDesc(
+			registry.MetricsPrefix+"interface_status_changes_total",
+			"The number of times the interface status has changed between scrapes.",
+			[]string{"host", "name", "descr", "hwif"},
+			nil,
+		),
+		interfaceCounters: []statCounter[InterfaceStats]{
+			newStatCounter("interface_in_errs_total", "The total number of input errors on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.InErrs }),
+			newStatCounter("interface_out_errs_total", "The total number of output errors on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.OutErrs }),
+			newStatCounter("interface_collisions_total", "The total number of collisions on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.Collisions }),
+			newStatCounter("interface_in_bytes_total", "The total number of input bytes on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.InBytes }),
+			newStatCounter("interface_in_pass_bytes_total", "The total number of input bytes passed on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.InBytesPass }),
+			newStatCounter("interface_out_bytes_total", "The total number of output bytes on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.OutBytes }),
+			newStatCounter("interface_out_pass_bytes_total", "The total number of output bytes passed on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.OutBytesPass }),
+			newStatCounter("interface_in_pkts_total", "The total number of input packets handled by the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.InPkts }),
+			newStatCounter("interface_in_pass_pkts_total", "The total number of input packets passed on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.InPktsPass }),
+			newStatCounter("interface_out_pkts_total", "The total number of output packets handled by the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.OutPkts }),
+			newStatCounter("interface_out_pass_pkts_total", "The total number of output packets passed on the interface.", counterLabels, func(iface InterfaceStats) float64 { return iface.OutPktsPass }),
+		},
+		counters: newCounterTracker(),
+		statuses: make(map[string]map[string]*interfaceStatusState),
 	}
 }
 
@@ -157,6 +236,13 @@ func (c *InterfaceCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.interfaceInPktsPassCount.Describe(ch)
 	c.interfaceOutPktsCount.Describe(ch)
 	c.interfaceOutPktsPassCount.Describe(ch)
+	c.interfaceSpeedBytes.Describe(ch)
+	c.interfaceMTUBytes.Describe(ch)
+	c.interfaceInfo.Describe(ch)
+	ch <- c.interfaceStatusChanges
+	for _, counter := range c.interfaceCounters {
+		ch <- counter.desc
+	}
 }
 
 // Collect fetches the stats and sends them to the channel.
@@ -197,8 +283,49 @@ func (c *InterfaceCollector) CollectWithTarget(ch chan<- prometheus.Metric, targ
 		c.interfaceInPktsPassCount.WithLabelValues(target.Host, iface.Name, iface.Descr, iface.Hwif).Set(float64(iface.InPktsPass))
 		c.interfaceOutPktsCount.WithLabelValues(target.Host, iface.Name, iface.Descr, iface.Hwif).Set(float64(iface.OutPkts))
 		c.interfaceOutPktsPassCount.WithLabelValues(target.Host, iface.Name, iface.Descr, iface.Hwif).Set(float64(iface.OutPktsPass))
+		c.interfaceMTUBytes.WithLabelValues(target.Host, iface.Name, iface.Descr, iface.Hwif).Set(iface.MTU)
+		c.interfaceInfo.WithLabelValues(
+			target.Host,
+			iface.Name,
+			iface.Descr,
+			iface.Hwif,
+			iface.Media,
+			iface.MACAddr,
+			iface.IPAddr,
+			iface.IPAddrV6,
+			iface.Gateway,
+			iface.GatewayV6,
+			strconv.FormatBool(iface.Enable),
+		).Set(1)
+
+		// Only report the link speed if it can be determined from the media
+		if speed, ok := interfaceMediaToSpeedBytes(iface.Media); ok {
+			c.interfaceSpeedBytes.WithLabelValues(target.Host, iface.Name, iface.Descr, iface.Hwif).Set(speed)
+		}
+
+		// Emit the cumulative statistics as counters, accounting for counter resets on the host
+		for _, counter := range c.interfaceCounters {
+			ch <- prometheus.MustNewConstMetric(
+				counter.desc,
+				prometheus.CounterValue,
+				c.counters.Observe(target.Host, iface.Name+"/"+counter.name, counter.value(iface)),
+				target.Host, iface.Name, iface.Descr, iface.Hwif,
+			)
+		}
+
+		// Track status changes between scrapes to detect flapping links
+		ch <- prometheus.MustNewConstMetric(
+			c.interfaceStatusChanges,
+			prometheus.CounterValue,
+			c.observeStatus(target.Host, iface.Name, iface.Status),
+			target.Host, iface.Name, iface.Descr, iface.Hwif,
+		)
 	}
 
+	// Stop tracking the counters and statuses of interfaces that no longer exist
+	c.counters.Prune(target.Host)
+	c.pruneStatuses(target.Host)
+
 	// Collect the metrics
 	c.interfaceUp.Collect(ch)
 	c.interfaceInErrsCount.Collect(ch)
@@ -212,6 +339,9 @@ func (c *InterfaceCollector) CollectWithTarget(ch chan<- prometheus.Metric, targ
 	c.interfaceInPktsPassCount.Collect(ch)
 	c.interfaceOutPktsCount.Collect(ch)
 	c.interfaceOutPktsPassCount.Collect(ch)
+	c.interfaceSpeedBytes.Collect(ch)
+	c.interfaceMTUBytes.Collect(ch)
+	c.interfaceInfo.Collect(ch)
 }
 
 // resetMetrics resets all metrics in the collector.
@@ -228,6 +358,54 @@ func (c *InterfaceCollector) resetMetrics() {
 	c.interfaceInPktsPassCount.Reset()
 	c.interfaceOutPktsCount.Reset()
 	c.interfaceOutPktsPassCount.Reset()
+	c.interfaceSpeedBytes.Reset()
+	c.interfaceMTUBytes.Reset()
+	c.interfaceInfo.Reset()
+}
+
+// observeStatus records the current status of an interface and returns the number of status changes seen so far.
+func (c *InterfaceCollector) observeStatus(host string, name string, status string) float64 {
+	c.statusesMu.Lock()
+	defer c.statusesMu.Unlock()
+
+	// Start tracking the interface the first time it is seen
+	statuses, ok := c.statuses[host]
+	if !ok {
+		statuses = make(map[string]*interfaceStatusState)
+		c.statuses[host] = statuses
+	}
+	state, ok := statuses[name]
+	if !ok {
+		statuses[name] = &interfaceStatusState{status: status, seen: true}
+		return 0
+	}
+
+	// Count the change if the status differs from the previous scrape
+	if state.status != status {
+		state.status = status
+		state.changes++
+	}
+	state.seen = true
+
+	return state.changes
+}
+
+// pruneStatuses stops tracking the statuses of a host's interfaces that were not observed since the previous call,
+// e.g. interfaces that were removed. It should be called once per scrape after all statuses were observed.
+func (c *InterfaceCollector) pruneStatuses(host string) {
+	c.statusesMu.Lock()
+	defer c.statusesMu.Unlock()
+
+	for name, state := range c.statuses[host] {
+		if !state.seen {
+			delete(c.statuses[host], name)
+			continue
+		}
+		state.seen = false
+	}
+	if len(c.statuses[host]) == 0 {
+		delete(c.statuses, host)
+	}
 }
 
 // statusToFloat64 converts the interface status string to a float64 for Prometheus metrics.
@@ -237,3 +415,22 @@ func interfaceStatusToFloat64(status string) float64 {
 	}
 	return 0.0
 }
+
+// interfaceMediaToSpeedBytes converts the link speed found in an interface media string to bytes per second.
+func interfaceMediaToSpeedBytes(media string) (float64, bool) {
+	match := interfaceMediaSpeedRegex.FindStringSubmatch(media)
+	if match == nil {
+		return 0, false
+	}
+
+	// Media speeds are expressed in Mbit/s unless suffixed with G for Gbit/s
+	speed, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	if match[2] != "" {
+		speed *= 1000
+	}
+
+	return speed * 1000000 / 8, true
+}
@@ -53,6 +53,24 @@ func TestNewInterfaceCollector(t *testing.T) {
 	if collector.interfaceOutPktsPassCount == nil {
 		t.Error("Expected interfaceOutPktsPassCount metric to be initialized")
 	}
+	if collector.interfaceSpeedBytes == nil {
+		t.Error("Expected interfaceSpeedBytes metric to be initialized")
+	}
+	if collector.interfaceMTUBytes == nil {
+		t.Error("Expected interfaceMTUBytes metric to be initialized")
+	}
+	if collector.interfaceInfo == nil {
+		t.Error("Expected interfaceInfo metric to be initialized")
+	}
+	if collector.interfaceStatusChanges == nil {
+		t.Error("Expected interfaceStatusChanges metric to be initialized")
+	}
+	if len(collector.interfaceCounters) != 11 {
+		t.Errorf("Expected 11 interface counters to be initialized, got %d", len(collector.interfaceCounters))
+	}
+	if collector.counters == nil {
+		t.Error("Expected counters tracker to be initialized")
+	}
 }
 
 func TestInterfaceCollectorName(t *testing.T) {
@@ -78,9 +96,9 @@ func TestInterfaceCollectorDescribe(t *testing.T) {
 		count++
 	}
 
-	// Should have 11 descriptions (all metrics except interfaceUp which is not in Describe)
-	if count != 11 {
-		t.Errorf("Expected 11 metric descriptions, got %d", count)
+	// Should have 26 descriptions (all metrics except interfaceUp which is not in Describe)
+	if count != 26 {
+		t.Errorf("Expected 26 metric descriptions, got %d", count)
 	}
 }
 
@@ -203,6 +221,83 @@ func TestInterfaceStatusToFloat64(t *testing.T) {
 	}
 }
 
+func TestInterfaceMediaToSpeedBytes(t *testing.T) {
+	tests := []struct {
+		media    string
+		expected float64
+		ok       bool
+	}{
+		{"1000baseT <full-duplex>", 125000000, true},
+		{"autoselect (100baseTX <half-duplex>)", 12500000, true},
+		{"10Gbase-T <full-duplex>", 1250000000, true},
+		{"2500Base-T <full-duplex>", 312500000, true},
+		{"autoselect", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.media, func(t *testing.T) {
+			speed, ok := interfaceMediaToSpeedBytes(tt.media)
+			if ok != tt.ok {
+				t.Errorf("Expected ok %t for media '%s', got %t", tt.ok, tt.media, ok)
+			}
+			if speed != tt.expected {
+				t.Errorf("Expected speed %f for media '%s', got %f", tt.expected, tt.media, speed)
+			}
+		})
+	}
+}
+
+func TestInterfaceCollectorObserveStatus(t *testing.T) {
+	collector := NewInterfaceCollector()
+
+	// The first observation should not count as a change
+	if changes := collector.observeStatus("test.com", "wan", "up"); changes != 0 {
+		t.Errorf("Expected 0 changes on first observation, got %f", changes)
+	}
+
+	// An unchanged status should not count as a change
+	if changes := collector.observeStatus("test.com", "wan", "up"); changes != 0 {
+		t.Errorf("Expected 0 changes for unchanged status, got %f", changes)
+	}
+
+	// Each transition should increment the counter
+	collector.observeStatus("test.com", "wan", "no carrier")
+	if changes := collector.observeStatus("test.com", "wan", "up"); changes != 2 {
+		t.Errorf("Expected 2 changes after flapping, got %f", changes)
+	}
+
+	// Interfaces on other hosts should be tracked separately
+	if changes := collector.observeStatus("other.com", "wan", "down"); changes != 0 {
+		t.Errorf("Expected 0 changes for a different host, got %f", changes)
+	}
+}
+
+func TestInterfaceCollectorPruneStatuses(t *testing.T) {
+	collector := NewInterfaceCollector()
+	collector.observeStatus("test.com", "wan", "up")
+	collector.observeStatus("test.com", "wan", "down")
+	collector.observeStatus("test.com", "lan", "up")
+	collector.pruneStatuses("test.com")
+
+	// Interfaces not observed since the previous prune should be forgotten
+	collector.observeStatus("test.com", "wan", "down")
+	collector.pruneStatuses("test.com")
+	if _, ok := collector.statuses["test.com"]["lan"]; ok {
+		t.Error("Expected the removed interface to be pruned")
+	}
+	if changes := collector.observeStatus("test.com", "wan", "down"); changes != 1 {
+		t.Errorf("Expected the remaining interface to keep its 1 change, got %f", changes)
+	}
+
+	// Hosts without any observed interfaces should be forgotten
+	collector.pruneStatuses("test.com")
+	collector.pruneStatuses("test.com")
+	if _, ok := collector.statuses["test.com"]; ok {
+		t.Error("Expected the host to be pruned")
+	}
+}
+
 func TestInterfaceStatsStruct(t *testing.T) {
 	stats := InterfaceStats{
 		Name:         "test",
@@ -0,0 +1,155 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewInterfaceTopologyCollector())
+}
+
+// InterfaceTopologyCollector collects metrics about VLAN, LAGG and bridge interfaces.
+type InterfaceTopologyCollector struct {
+	vlanInfo           *prometheus.GaugeVec
+	laggInfo           *prometheus.GaugeVec
+	laggMembersCount   *prometheus.GaugeVec
+	bridgeMembersCount *prometheus.GaugeVec
+	bridgeMemberInfo   *prometheus.GaugeVec
+}
+
+// VLANStats represents the structure of the VLAN data returned by the API.
+type VLANStats struct {
+	If     string `json:"if"`
+	Tag    int64  `json:"tag"`
+	VLANIf string `json:"vlanif"`
+	Descr  string `json:"descr"`
+}
+
+// LAGGStats represents the structure of the LAGG data returned by the API.
+type LAGGStats struct {
+	LAGGIf  string   `json:"laggif"`
+	Members []string `json:"members"`
+	Proto   string   `json:"proto"`
+	Descr   string   `json:"descr"`
+}
+
+// BridgeStats represents the structure of the bridge data returned by the API.
+type BridgeStats struct {
+	BridgeIf string   `json:"bridgeif"`
+	Members  []string `json:"members"`
+	Descr    string   `json:"descr"`
+}
+
+// NewInterfaceTopologyCollector is the constructor
+func NewInterfaceTopologyCollector() *InterfaceTopologyCollector {
+	return &InterfaceTopologyCollector{
+		vlanInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_vlan_info",
+				Help: "Contains details about the VLAN's tag and parent interface.",
+			},
+			[]string{"host", "vlanif", "parent", "tag", "descr"},
+		),
+		laggInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_lagg_info",
+				Help: "Contains details about the LAGG's protocol.",
+			},
+			[]string{"host", "laggif", "proto", "descr"},
+		),
+		laggMembersCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_lagg_members_count",
+				Help: "The number of member ports configured on the LAGG.",
+			},
+			[]string{"host", "laggif"},
+		),
+		bridgeMembersCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_bridge_members_count",
+				Help: "The number of member interfaces configured on the bridge.",
+			},
+			[]string{"host", "bridgeif", "descr"},
+		),
+		bridgeMemberInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "interface_bridge_member_info",
+				Help: "Contains details about the bridge's member interfaces.",
+			},
+			[]string{"host", "bridgeif", "member"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *InterfaceTopologyCollector) Name() string {
+	return "interface_topology"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *InterfaceTopologyCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.vlanInfo.Describe(ch)
+	c.laggInfo.Describe(ch)
+	c.laggMembersCount.Describe(ch)
+	c.bridgeMembersCount.Describe(ch)
+	c.bridgeMemberInfo.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *InterfaceTopologyCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each VLAN identified
+	var vlans []VLANStats
+	if err := utils.RequestData(target, "GET", "/api/v2/interface/vlans", &vlans); err != nil {
+		log.Error("interface_topology", "failed to fetch VLANs from host %s: %s", target.Host, err.Error())
+	}
+	for _, vlan := range vlans {
+		c.vlanInfo.WithLabelValues(target.Host, vlan.VLANIf, vlan.If, fmt.Sprintf("%d", vlan.Tag), vlan.Descr).Set(1)
+	}
+
+	// Extract metrics for each LAGG identified
+	var laggs []LAGGStats
+	if err := utils.RequestData(target, "GET", "/api/v2/interface/laggs", &laggs); err != nil {
+		log.Error("interface_topology", "failed to fetch LAGGs from host %s: %s", target.Host, err.Error())
+	}
+	for _, lagg := range laggs {
+		c.laggInfo.WithLabelValues(target.Host, lagg.LAGGIf, lagg.Proto, lagg.Descr).Set(1)
+		c.laggMembersCount.WithLabelValues(target.Host, lagg.LAGGIf).Set(float64(len(lagg.Members)))
+	}
+
+	// Extract metrics for each bridge identified
+	var bridges []BridgeStats
+	if err := utils.RequestData(target, "GET", "/api/v2/interface/bridges", &bridges); err != nil {
+		log.Error("interface_topology", "failed to fetch bridges from host %s: %s", target.Host, err.Error())
+	}
+	for _, bridge := range bridges {
+		c.bridgeMembersCount.WithLabelValues(target.Host, bridge.BridgeIf, bridge.Descr).Set(float64(len(bridge.Members)))
+		for _, member := range bridge.Members {
+			c.bridgeMemberInfo.WithLabelValues(target.Host, bridge.BridgeIf, member).Set(1)
+		}
+	}
+
+	// Collect the metrics
+	c.vlanInfo.Collect(ch)
+	c.laggInfo.Collect(ch)
+	c.laggMembersCount.Collect(ch)
+	c.bridgeMembersCount.Collect(ch)
+	c.bridgeMemberInfo.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *InterfaceTopologyCollector) resetMetrics() {
+	c.vlanInfo.Reset()
+	c.laggInfo.Reset()
+	c.laggMembersCount.Reset()
+	c.bridgeMembersCount.Reset()
+	c.bridgeMemberInfo.Reset()
+}
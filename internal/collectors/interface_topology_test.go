@@ -0,0 +1,33 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestInterfaceTopologyCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/interface/vlans":   `[{"if":"lagg0","tag":10,"vlanif":"lagg0.10","descr":"Servers"}]`,
+		"/api/v2/interface/laggs":   `[{"laggif":"lagg0","members":["igb0","igb1"],"proto":"lacp","descr":"Uplink"}]`,
+		"/api/v2/interface/bridges": `[{"bridgeif":"bridge0","members":["lan","opt1"],"descr":"LAN bridge"}]`,
+	})
+
+	samples := collectTestSamples(t, NewInterfaceTopologyCollector(), target)
+
+	// Verify the VLAN is mapped to its parent
+	if _, ok := findTestSample(samples, "pfsense_interface_vlan_info", map[string]string{"vlanif": "lagg0.10", "parent": "lagg0", "tag": "10"}); !ok {
+		t.Error("Expected VLAN info metric for lagg0.10")
+	}
+
+	// Verify the LAGG's protocol and members
+	if _, ok := findTestSample(samples, "pfsense_interface_lagg_info", map[string]string{"laggif": "lagg0", "proto": "lacp", "descr": "Uplink"}); !ok {
+		t.Error("Expected LAGG info metric for lagg0")
+	}
+	if sample, ok := findTestSample(samples, "pfsense_interface_lagg_members_count", map[string]string{"laggif": "lagg0"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 LAGG members, got %v", sample.value)
+	}
+
+	// Verify bridge membership
+	if count := countTestSamples(samples, "pfsense_interface_bridge_member_info"); count != 2 {
+		t.Errorf("Expected 2 bridge member metrics, got %d", count)
+	}
+}
@@ -0,0 +1,101 @@
+package collectors
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+// logCursorTailSize is the number of trailing lines remembered to find the position reached in a log.
+const logCursorTailSize = 3
+
+// LogEntry represents the structure of a log line returned by the API's log endpoints.
+type LogEntry struct {
+	Text string `json:"text"`
+}
+
+// fetchLogLines fetches the lines of the given log (e.g. firewall or system) from the target.
+func fetchLogLines(target *utils.Target, name string) ([]string, error) {
+	var entries []LogEntry
+	if err := utils.RequestData(target, "GET", "/api/v2/status/logs/"+name, &entries); err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, entry.Text)
+	}
+	return lines, nil
+}
+
+// logCursor tracks the position reached in a log between scrapes so each line is only processed once. The
+// position is remembered as the last few lines seen since the API doesn't expose stable line numbers.
+type logCursor struct {
+	tails map[string][]string
+	mu    sync.Mutex
+}
+
+// newLogCursor is the constructor
+func newLogCursor() *logCursor {
+	return &logCursor{tails: make(map[string][]string)}
+}
+
+// Advance returns the lines added since the previous call for the given key and moves the cursor to the end of
+// the lines. The first call only positions the cursor and returns nothing, so lines logged before the exporter
+// started are not counted. If the previous position can't be found (e.g. the log was rotated or more lines were
+// logged than the API returned), the cursor is repositioned and nothing is returned since the new lines can't be
+// told apart from the ones already counted.
+func (c *logCursor) Advance(key string, lines []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tail, seen := c.tails[key]
+	c.tails[key] = slices.Clone(lines[max(0, len(lines)-logCursorTailSize):])
+	if !seen {
+		return nil
+	}
+
+	// All lines are new if the log was empty when the cursor was last positioned
+	if len(tail) == 0 {
+		return lines
+	}
+
+	// Find the latest occurrence of the whole remembered tail and return the lines after it. Lines include their
+	// timestamp, so a full tail match is unlikely to be mistaken for lines logged at a different time.
+	for end := len(lines); end >= len(tail); end-- {
+		if slices.Equal(lines[end-len(tail):end], tail) {
+			return lines[end:]
+		}
+	}
+	return nil
+}
+
+// logTotals accumulates the counts derived from log lines per host so they can be exposed as counters.
+type logTotals[K comparable] struct {
+	totals map[string]map[K]float64
+	mu     sync.Mutex
+}
+
+// newLogTotals is the constructor
+func newLogTotals[K comparable]() *logTotals[K] {
+	return &logTotals[K]{totals: make(map[string]map[K]float64)}
+}
+
+// Add adds the counts to the host's totals and returns a copy of the host's updated totals.
+func (t *logTotals[K]) Add(host string, counts map[K]float64) map[K]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	totals, ok := t.totals[host]
+	if !ok {
+		totals = make(map[K]float64)
+		t.totals[host] = totals
+	}
+	for key, count := range counts {
+		totals[key] += count
+	}
+
+	return maps.Clone(totals)
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLogCursorAdvance(t *testing.T) {
+	cursor := newLogCursor()
+
+	// The first call should only position the cursor
+	if lines := cursor.Advance("a", []string{"1", "2", "3"}); len(lines) != 0 {
+		t.Errorf("Expected no lines on first call, got %v", lines)
+	}
+
+	// Only lines after the previous position should be returned
+	if lines := cursor.Advance("a", []string{"1", "2", "3", "4", "5"}); !slices.Equal(lines, []string{"4", "5"}) {
+		t.Errorf("Expected [4 5], got %v", lines)
+	}
+
+	// Lines dropped from the start of the log should not affect the position
+	if lines := cursor.Advance("a", []string{"3", "4", "5", "6"}); !slices.Equal(lines, []string{"6"}) {
+		t.Errorf("Expected [6], got %v", lines)
+	}
+
+	// No new lines should return nothing
+	if lines := cursor.Advance("a", []string{"3", "4", "5", "6"}); len(lines) != 0 {
+		t.Errorf("Expected no lines without changes, got %v", lines)
+	}
+
+	// Nothing should be returned if the previous position is gone (e.g. the log was rotated)
+	if lines := cursor.Advance("a", []string{"7", "8"}); len(lines) != 0 {
+		t.Errorf("Expected no lines after rotation, got %v", lines)
+	}
+
+	// The cursor should be repositioned after the position was lost
+	if lines := cursor.Advance("a", []string{"7", "8", "9"}); !slices.Equal(lines, []string{"9"}) {
+		t.Errorf("Expected [9] after repositioning, got %v", lines)
+	}
+
+	// A partial match of the remembered tail should not be treated as the previous position
+	cursor.Advance("d", []string{"1", "2", "3"})
+	if lines := cursor.Advance("d", []string{"3", "4"}); len(lines) != 0 {
+		t.Errorf("Expected no lines for a partial tail match, got %v", lines)
+	}
+
+	// Repeated lines should be matched by the remembered tail rather than the last line alone
+	cursor.Advance("b", []string{"x", "y", "y"})
+	if lines := cursor.Advance("b", []string{"x", "y", "y", "y"}); !slices.Equal(lines, []string{"y"}) {
+		t.Errorf("Expected [y] for repeated lines, got %v", lines)
+	}
+
+	// Lines logged after the log was empty should all be returned
+	cursor.Advance("c", nil)
+	if lines := cursor.Advance("c", []string{"1"}); !slices.Equal(lines, []string{"1"}) {
+		t.Errorf("Expected [1] after empty log, got %v", lines)
+	}
+}
+
+func TestLogTotalsAdd(t *testing.T) {
+	totals := newLogTotals[string]()
+
+	totals.Add("a", map[string]float64{"x": 1, "y": 2})
+	snapshot := totals.Add("a", map[string]float64{"x": 3})
+	if snapshot["x"] != 4 || snapshot["y"] != 2 {
+		t.Errorf("Expected x=4 and y=2, got %v", snapshot)
+	}
+
+	// Other hosts should be tracked separately
+	if snapshot := totals.Add("b", map[string]float64{"x": 1}); snapshot["x"] != 1 || len(snapshot) != 1 {
+		t.Errorf("Expected only x=1 for a separate host, got %v", snapshot)
+	}
+
+	// The returned snapshot should not be affected by later additions
+	totals.Add("a", map[string]float64{"x": 1})
+	if snapshot["x"] != 4 {
+		t.Errorf("Expected snapshot to be unchanged, got %v", snapshot)
+	}
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"encoding/json"
+	"net/url"
 
 	"github.com/pfrest/pfsense_exporter/internal/log"
 	"github.com/pfrest/pfsense_exporter/internal/registry"
@@ -59,21 +59,10 @@ func (c *LoginProtectionCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect fetches the stats and sends them to the channel.
 func (c *LoginProtectionCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
-	// Collect metrics for each target
-	resp, err := utils.Request(target, "GET", "/api/v2/diagnostics/table?id=sshguard")
+	// Collect the sshguard table from the target
+	entries, err := fetchTableEntries(target, "sshguard")
 	if err != nil {
-		log.Error(target.Host, "failed to fetch Login Protection's sshguard table: "+err.Error())
-		return
-	}
-	if resp == nil || resp.Data == nil {
-		log.Error("login_protection", "received nil response from host %s", target.Host)
-		return
-	}
-
-	// Unmarshal the response data into a LoginProtectionStats struct
-	var stats LoginProtectionStats
-	if err := json.Unmarshal(resp.Data, &stats); err != nil {
-		log.Error("login_protection", "failed to unmarshal Login Protection response from host %s: %s", target.Host, err.Error())
+		log.Error("login_protection", "failed to fetch Login Protection's sshguard table from host %s: %s", target.Host, err.Error())
 		return
 	}
 
@@ -81,10 +70,10 @@ func (c *LoginProtectionCollector) CollectWithTarget(ch chan<- prometheus.Metric
 	c.resetMetrics()
 
 	// Update the metrics
-	for _, entry := range stats.Entries {
+	for _, entry := range entries {
 		c.loginProtectionBlockedIP.WithLabelValues(target.Host, entry).Set(1)
 	}
-	c.loginProtectionBlockedIPCount.WithLabelValues(target.Host).Set(float64(len(stats.Entries)))
+	c.loginProtectionBlockedIPCount.WithLabelValues(target.Host).Set(float64(len(entries)))
 
 	// Collect the metrics
 	c.loginProtectionBlockedIP.Collect(ch)
@@ -96,3 +85,12 @@ func (c *LoginProtectionCollector) resetMetrics() {
 	c.loginProtectionBlockedIP.Reset()
 	c.loginProtectionBlockedIPCount.Reset()
 }
+
+// fetchTableEntries fetches the entries of a pf table (e.g. sshguard or snort2c) from the target.
+func fetchTableEntries(target *utils.Target, table string) ([]string, error) {
+	var stats LoginProtectionStats
+	if err := utils.RequestData(target, "GET", "/api/v2/diagnostics/table?id="+url.QueryEscape(table), &stats); err != nil {
+		return nil, err
+	}
+	return stats.Entries, nil
+}
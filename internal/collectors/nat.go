@@ -0,0 +1,327 @@
+package collectors
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// natOutboundModes contains the outbound NAT modes supported by pfSense. The 'advanced' mode is manual outbound NAT.
+var natOutboundModes = []string{"automatic", "hybrid", "advanced", "disabled"}
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewNATCollector())
+}
+
+// NATCollector collects metrics about port forward, outbound and 1:1 NAT rules.
+type NATCollector struct {
+	natRulesCount     *prometheus.GaugeVec
+	natOutboundMode   *prometheus.GaugeVec
+	natRuleInfo       *prometheus.GaugeVec
+	natRuleStateCount *prometheus.GaugeVec
+}
+
+// NATPortForwardStats represents the structure of the port forward data returned by the API.
+type NATPortForwardStats struct {
+	ID               int64  `json:"id"`
+	Interface        string `json:"interface"`
+	Protocol         string `json:"protocol"`
+	Destination      string `json:"destination"`
+	DestinationPort  string `json:"destination_port"`
+	Target           string `json:"target"`
+	LocalPort        string `json:"local_port"`
+	Disabled         bool   `json:"disabled"`
+	Descr            string `json:"descr"`
+	AssociatedRuleID string `json:"associated_rule_id"`
+}
+
+// NATOutboundMappingStats represents the structure of the outbound NAT mapping data returned by the API.
+type NATOutboundMappingStats struct {
+	ID              int64  `json:"id"`
+	Interface       string `json:"interface"`
+	Protocol        string `json:"protocol"`
+	Source          string `json:"source"`
+	Destination     string `json:"destination"`
+	DestinationPort string `json:"destination_port"`
+	Target          string `json:"target"`
+	NATPort         string `json:"natport"`
+	Disabled        bool   `json:"disabled"`
+	Descr           string `json:"descr"`
+}
+
+// NATOneToOneMappingStats represents the structure of the 1:1 NAT mapping data returned by the API.
+type NATOneToOneMappingStats struct {
+	ID          int64  `json:"id"`
+	Interface   string `json:"interface"`
+	IPProtocol  string `json:"ipprotocol"`
+	External    string `json:"external"`
+	Internal    string `json:"internal"`
+	Destination string `json:"destination"`
+	Disabled    bool   `json:"disabled"`
+	Descr       string `json:"descr"`
+}
+
+// NATOutboundModeStats represents the structure of the outbound NAT mode data returned by the API.
+type NATOutboundModeStats struct {
+	Mode string `json:"mode"`
+}
+
+// FirewallRuleStats represents the structure of the firewall rule data returned by the API.
+type FirewallRuleStats struct {
+	ID               int64    `json:"id"`
+	Tracker          int64    `json:"tracker"`
+	Interface        []string `json:"interface"`
+	Protocol         string   `json:"protocol"`
+	Destination      string   `json:"destination"`
+	DestinationPort  string   `json:"destination_port"`
+	AssociatedRuleID string   `json:"associated_rule_id"`
+}
+
+// NewNATCollector is the constructor
+func NewNATCollector() *NATCollector {
+	return &NATCollector{
+		natRulesCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "nat_rules_count",
+				Help: "The number of NAT rules configured by type (port_forward, outbound or one_to_one).",
+			},
+			[]string{"host", "type"},
+		),
+		natOutboundMode: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "nat_outbound_mode",
+				Help: "Whether the outbound NAT mode is in use (1) or not (0). The 'advanced' mode is manual outbound NAT.",
+			},
+			[]string{"host", "mode"},
+		),
+		natRuleInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "nat_rule_info",
+				Help: "Contains details about the NAT rule's interface, protocol, destination and target.",
+			},
+			[]string{"host", "type", "id", "interface", "protocol", "destination", "destination_port", "target", "target_port", "descr", "disabled"},
+		),
+		natRuleStateCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "nat_rule_states_count",
+				Help: "The number of firewall states matching the port forward's associated filter rule.",
+			},
+			[]string{"host", "type", "id"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *NATCollector) Name() string {
+	return "nat"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *NATCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.natRulesCount.Describe(ch)
+	c.natOutboundMode.Describe(ch)
+	c.natRuleInfo.Describe(ch)
+	c.natRuleStateCount.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *NATCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each port forward identified
+	var portForwards []NATPortForwardStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/nat/port_forwards", &portForwards); err != nil {
+		log.Error("nat", "failed to fetch port forwards from host %s: %s", target.Host, err.Error())
+	} else {
+		c.natRulesCount.WithLabelValues(target.Host, "port_forward").Set(float64(len(portForwards)))
+	}
+	for _, rule := range portForwards {
+		c.natRuleInfo.WithLabelValues(
+			target.Host, "port_forward", fmt.Sprintf("%d", rule.ID), rule.Interface, rule.Protocol,
+			rule.Destination, rule.DestinationPort, rule.Target, rule.LocalPort, rule.Descr,
+			strconv.FormatBool(rule.Disabled),
+		).Set(1)
+	}
+	if target.NAT.StateCounts {
+		c.collectPortForwardStates(target, portForwards)
+	}
+
+	// Extract metrics for the outbound NAT mode and each outbound mapping identified
+	var mode NATOutboundModeStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/nat/outbound/mode", &mode); err != nil {
+		log.Error("nat", "failed to fetch outbound NAT mode from host %s: %s", target.Host, err.Error())
+	} else {
+		for _, m := range natOutboundModes {
+			c.natOutboundMode.WithLabelValues(target.Host, m).Set(utils.BoolToFloat64(m == mode.Mode))
+		}
+	}
+	var outbound []NATOutboundMappingStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/nat/outbound/mappings", &outbound); err != nil {
+		log.Error("nat", "failed to fetch outbound NAT mappings from host %s: %s", target.Host, err.Error())
+	} else {
+		c.natRulesCount.WithLabelValues(target.Host, "outbound").Set(float64(len(outbound)))
+	}
+	for _, rule := range outbound {
+		c.natRuleInfo.WithLabelValues(
+			target.Host, "outbound", fmt.Sprintf("%d", rule.ID), rule.Interface, rule.Protocol,
+			rule.Destination, rule.DestinationPort, rule.Target, rule.NATPort, rule.Descr,
+			strconv.FormatBool(rule.Disabled),
+		).Set(1)
+	}
+
+	// Extract metrics for each 1:1 mapping identified
+	var oneToOne []NATOneToOneMappingStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/nat/one_to_one/mappings", &oneToOne); err != nil {
+		log.Error("nat", "failed to fetch 1:1 NAT mappings from host %s: %s", target.Host, err.Error())
+	} else {
+		c.natRulesCount.WithLabelValues(target.Host, "one_to_one").Set(float64(len(oneToOne)))
+	}
+	for _, rule := range oneToOne {
+		c.natRuleInfo.WithLabelValues(
+			target.Host, "one_to_one", fmt.Sprintf("%d", rule.ID), rule.Interface, rule.IPProtocol,
+			rule.External, "", rule.Internal, "", rule.Descr,
+			strconv.FormatBool(rule.Disabled),
+		).Set(1)
+	}
+
+	// Collect the metrics
+	c.natRulesCount.Collect(ch)
+	c.natOutboundMode.Collect(ch)
+	c.natRuleInfo.Collect(ch)
+	c.natRuleStateCount.Collect(ch)
+}
+
+// collectPortForwardStates counts the firewall states matching the filter rule associated with each port forward.
+func (c *NATCollector) collectPortForwardStates(target *utils.Target, portForwards []NATPortForwardStats) {
+	// Only port forwards with an associated filter rule can be matched to states
+	associated := slices.ContainsFunc(portForwards, func(rule NATPortForwardStats) bool {
+		return rule.AssociatedRuleID != ""
+	})
+	if !associated {
+		return
+	}
+
+	var filterRules []FirewallRuleStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/rules", &filterRules); err != nil {
+		log.Error("nat", "failed to fetch firewall rules from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Map each port forward to its associated filter rule
+	matches := make(map[int64]FirewallRuleStats)
+	for _, rule := range portForwards {
+		if rule.AssociatedRuleID == "" {
+			continue
+		}
+		for _, filterRule := range filterRules {
+			if filterRule.AssociatedRuleID == rule.AssociatedRuleID {
+				matches[rule.ID] = filterRule
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	// States report the physical interface, so map the interfaces of the filter rules to their physical interfaces
+	var interfaces []InterfaceStats
+	if err := utils.RequestData(target, "GET", "/api/v2/status/interfaces", &interfaces); err != nil {
+		log.Error("nat", "failed to fetch interface statuses from host %s: %s", target.Host, err.Error())
+		return
+	}
+	hwifs := make(map[string]string, len(interfaces))
+	for _, iface := range interfaces {
+		hwifs[iface.Name] = iface.Hwif
+	}
+
+	// Count the states passed by each associated filter rule
+	counts := make(map[int64]int, len(matches))
+	_, _, err := pageFirewallStates(target, target.NAT.PageSize, target.NAT.MaxStates, func(state FirewallStateEntry) {
+		for id, filterRule := range matches {
+			if natFilterRuleMatchesState(filterRule, hwifs, state) {
+				counts[id]++
+			}
+		}
+	})
+	if err != nil {
+		log.Error("nat", "failed to fetch firewall states from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	for id := range matches {
+		c.natRuleStateCount.WithLabelValues(target.Host, "port_forward", fmt.Sprintf("%d", id)).Set(float64(counts[id]))
+	}
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *NATCollector) resetMetrics() {
+	c.natRulesCount.Reset()
+	c.natOutboundMode.Reset()
+	c.natRuleInfo.Reset()
+	c.natRuleStateCount.Reset()
+}
+
+// natFilterRuleMatchesState checks whether a firewall state was created by traffic on the filter rule's interface to
+// its destination address, port and protocol. hwifs maps interface names (e.g. wan) to physical interfaces. Floating
+// states (interface 'all') don't record the interface they were created on, so only their other fields are matched.
+func natFilterRuleMatchesState(rule FirewallRuleStats, hwifs map[string]string, state FirewallStateEntry) bool {
+	// The interface must be one of the filter rule's interfaces
+	if state.Interface != "all" && !slices.ContainsFunc(rule.Interface, func(iface string) bool {
+		return state.Interface == iface || state.Interface == hwifs[iface]
+	}) {
+		return false
+	}
+
+	// The protocol must match, where 'tcp/udp' matches either protocol
+	if rule.Protocol != "" && !slices.Contains(strings.Split(rule.Protocol, "/"), strings.ToLower(state.Protocol)) {
+		return false
+	}
+
+	address, port := firewallStateEndpoint(state.Destination)
+	if rule.Destination != address {
+		return false
+	}
+
+	return natPortInRange(port, rule.DestinationPort)
+}
+
+// natPortInRange checks whether a port falls within a port or port range (e.g. 443, 8000:8010 or 8000-8010).
+// An empty port range matches any port.
+func natPortInRange(port string, portRange string) bool {
+	if portRange == "" {
+		return true
+	}
+
+	value, err := strconv.Atoi(port)
+	if err != nil {
+		return false
+	}
+
+	// Split the range into its lower and upper bounds, which are equal for a single port
+	lower, upper, found := strings.Cut(portRange, ":")
+	if !found {
+		lower, upper, found = strings.Cut(portRange, "-")
+	}
+	if !found {
+		upper = lower
+	}
+	low, err := strconv.Atoi(lower)
+	if err != nil {
+		return false
+	}
+	high, err := strconv.Atoi(upper)
+	if err != nil {
+		return false
+	}
+
+	return value >= low && value <= high
+}
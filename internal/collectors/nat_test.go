@@ -0,0 +1,104 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestNATCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/nat/port_forwards": `[
+			{"id":0,"interface":"wan","protocol":"tcp","destination":"wanip","destination_port":"8080","target":"192.168.1.10","local_port":"80","descr":"Web","associated_rule_id":"nat_1"},
+			{"id":1,"interface":"wan","protocol":"tcp/udp","destination":"wanip","destination_port":"5000","target":"192.168.1.11","local_port":"5000","descr":"Unmatched","associated_rule_id":""}
+		]`,
+		"/api/v2/firewall/nat/outbound/mode":       `{"mode":"hybrid"}`,
+		"/api/v2/firewall/nat/outbound/mappings":   `[{"id":0,"interface":"wan","protocol":"any","source":"192.168.1.0/24","destination":"any","target":"wanip"}]`,
+		"/api/v2/firewall/nat/one_to_one/mappings": `[{"id":0,"interface":"wan","ipprotocol":"inet","external":"203.0.113.10","internal":"192.168.1.20","destination":"any"}]`,
+		"/api/v2/firewall/rules":                   `[{"id":3,"tracker":100,"interface":["wan"],"protocol":"tcp","destination":"192.168.1.10","destination_port":"80","associated_rule_id":"nat_1"}]`,
+		"/api/v2/status/interfaces":                `[{"name":"wan","hwif":"igb0"},{"name":"lan","hwif":"igb1"}]`,
+		"/api/v2/firewall/states": `[
+			{"interface":"igb0","protocol":"tcp","direction":"in","source":"203.0.113.5:40000","destination":"192.168.1.10:80 (203.0.113.1:8080)","state":"ESTABLISHED:ESTABLISHED"},
+			{"interface":"all","protocol":"tcp","direction":"in","source":"203.0.113.6:40000","destination":"192.168.1.10:80","state":"ESTABLISHED:ESTABLISHED"},
+			{"interface":"igb0","protocol":"udp","direction":"in","source":"203.0.113.6:40000","destination":"192.168.1.10:80","state":"MULTIPLE:SINGLE"},
+			{"interface":"igb1","protocol":"tcp","direction":"out","source":"192.168.1.11:50000","destination":"192.168.1.10:80","state":"ESTABLISHED:ESTABLISHED"},
+			{"interface":"igb1","protocol":"tcp","direction":"out","source":"192.168.1.10:50000","destination":"198.51.100.1:443","state":"ESTABLISHED:ESTABLISHED"}
+		]`,
+	})
+	target.NAT.StateCounts = true
+
+	samples := collectTestSamples(t, NewNATCollector(), target)
+
+	// Verify the rule counts by type
+	if sample, ok := findTestSample(samples, "pfsense_nat_rules_count", map[string]string{"type": "port_forward"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 port forwards, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_nat_rules_count", map[string]string{"type": "one_to_one"}); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 1:1 mapping, got %v", sample.value)
+	}
+
+	// Verify the outbound NAT mode
+	if sample, ok := findTestSample(samples, "pfsense_nat_outbound_mode", map[string]string{"mode": "hybrid"}); !ok || sample.value != 1 {
+		t.Errorf("Expected hybrid mode to be in use, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_nat_outbound_mode", map[string]string{"mode": "automatic"}); !ok || sample.value != 0 {
+		t.Errorf("Expected automatic mode not to be in use, got %v", sample.value)
+	}
+
+	// Verify the rule info
+	if count := countTestSamples(samples, "pfsense_nat_rule_info"); count != 4 {
+		t.Errorf("Expected 4 rule info metrics, got %d", count)
+	}
+	if _, ok := findTestSample(samples, "pfsense_nat_rule_info", map[string]string{"type": "port_forward", "id": "0", "destination_port": "8080", "target": "192.168.1.10", "target_port": "80"}); !ok {
+		t.Error("Expected port forward info metric")
+	}
+	if _, ok := findTestSample(samples, "pfsense_nat_rule_info", map[string]string{"type": "one_to_one", "protocol": "inet", "target": "192.168.1.20"}); !ok {
+		t.Error("Expected 1:1 mapping info metric with its IP protocol")
+	}
+
+	// Only the port forward with an associated rule should have a state count, excluding states on other interfaces
+	if count := countTestSamples(samples, "pfsense_nat_rule_states_count"); count != 1 {
+		t.Errorf("Expected 1 rule state count, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_nat_rule_states_count", map[string]string{"id": "0"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 states for the port forward, got %v", sample.value)
+	}
+}
+
+func TestNATCollectorCollectWithTargetStateCountsDisabled(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/firewall/nat/port_forwards": `[{"id":0,"interface":"wan","protocol":"tcp","destination":"wanip","destination_port":"8080","target":"192.168.1.10","local_port":"80","associated_rule_id":"nat_1"}]`,
+		"/api/v2/firewall/rules":             `[{"id":3,"interface":["wan"],"protocol":"tcp","destination":"192.168.1.10","destination_port":"80","associated_rule_id":"nat_1"}]`,
+		"/api/v2/status/interfaces":          `[{"name":"wan","hwif":"igb0"}]`,
+		"/api/v2/firewall/states":            `[{"interface":"igb0","protocol":"tcp","direction":"in","source":"203.0.113.5:40000","destination":"192.168.1.10:80","state":"ESTABLISHED:ESTABLISHED"}]`,
+	})
+
+	// The state table should only be scanned when state counts are enabled
+	samples := collectTestSamples(t, NewNATCollector(), target)
+	if count := countTestSamples(samples, "pfsense_nat_rule_states_count"); count != 0 {
+		t.Errorf("Expected no rule state counts by default, got %d", count)
+	}
+}
+
+func TestNATPortInRange(t *testing.T) {
+	tests := []struct {
+		port      string
+		portRange string
+		expected  bool
+	}{
+		{"80", "80", true},
+		{"81", "80", false},
+		{"8005", "8000:8010", true},
+		{"8005", "8000-8010", true},
+		{"8011", "8000:8010", false},
+		{"80", "", true},
+		{"", "80", false},
+		{"80", "http", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.port+"_"+tt.portRange, func(t *testing.T) {
+			if result := natPortInRange(tt.port, tt.portRange); result != tt.expected {
+				t.Errorf("Expected %t for port '%s' in range '%s', got %t", tt.expected, tt.port, tt.portRange, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewNeighborsCollector())
+}
+
+// NeighborsCollector collects metrics about the ARP neighbor table.
+type NeighborsCollector struct {
+	neighborsEntriesCount    *prometheus.GaugeVec
+	neighborsIncompleteCount *prometheus.GaugeVec
+	neighborsExpiredCount    *prometheus.GaugeVec
+	neighborsEntryInfo       *prometheus.GaugeVec
+	neighborsEntryTruncated  *prometheus.GaugeVec
+}
+
+// NeighborEntry represents the structure of an ARP table entry returned by the API.
+type NeighborEntry struct {
+	IPAddress  string `json:"ip_address"`
+	MACAddress string `json:"mac_address"`
+	Hostname   string `json:"hostname"`
+	Interface  string `json:"interface"`
+	Expires    string `json:"expires"`
+}
+
+// NewNeighborsCollector is the constructor
+func NewNeighborsCollector() *NeighborsCollector {
+	return &NeighborsCollector{
+		neighborsEntriesCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "neighbors_entries_count",
+				Help: "The number of entries in the ARP table for the interface.",
+			},
+			[]string{"host", "interface"},
+		),
+		neighborsIncompleteCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "neighbors_incomplete_entries_count",
+				Help: "The number of incomplete entries in the ARP table for the interface.",
+			},
+			[]string{"host", "interface"},
+		),
+		neighborsExpiredCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "neighbors_expired_entries_count",
+				Help: "The number of expired entries in the ARP table for the interface.",
+			},
+			[]string{"host", "interface"},
+		),
+		neighborsEntryInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "neighbors_entry_info",
+				Help: "Contains details about the ARP table entry's IP address, MAC address and hostname.",
+			},
+			[]string{"host", "interface", "ip_address", "mac_address", "hostname"},
+		),
+		neighborsEntryTruncated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "neighbors_entry_info_truncated",
+				Help: "Whether the per-entry info metrics were truncated by the configured max_entries (1) or not (0).",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *NeighborsCollector) Name() string {
+	return "neighbors"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *NeighborsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.neighborsEntriesCount.Describe(ch)
+	c.neighborsIncompleteCount.Describe(ch)
+	c.neighborsExpiredCount.Describe(ch)
+	c.neighborsEntryInfo.Describe(ch)
+	c.neighborsEntryTruncated.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *NeighborsCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Collect the ARP table from the target
+	var arp []NeighborEntry
+	if err := utils.RequestData(target, "GET", "/api/v2/diagnostics/arp_table", &arp); err != nil {
+		log.Error("neighbors", "failed to fetch ARP table from host %s: %s", target.Host, err.Error())
+	}
+
+	// Extract metrics for each ARP table entry identified, capping the number of per-entry metrics
+	entries := 0
+	for _, entry := range arp {
+		incomplete := neighborEntryIncomplete(entry)
+		expired := strings.Contains(strings.ToLower(entry.Expires), "expired")
+		c.neighborsEntriesCount.WithLabelValues(target.Host, entry.Interface).Inc()
+		c.neighborsIncompleteCount.WithLabelValues(target.Host, entry.Interface).Add(utils.BoolToFloat64(incomplete))
+		c.neighborsExpiredCount.WithLabelValues(target.Host, entry.Interface).Add(utils.BoolToFloat64(expired))
+
+		if !target.Neighbors.IncludeEntries || entries >= target.Neighbors.MaxEntries {
+			continue
+		}
+		c.neighborsEntryInfo.WithLabelValues(target.Host, entry.Interface, entry.IPAddress, entry.MACAddress, entry.Hostname).Set(1)
+		entries++
+	}
+	if target.Neighbors.IncludeEntries {
+		c.neighborsEntryTruncated.WithLabelValues(target.Host).Set(utils.BoolToFloat64(len(arp) > entries))
+	}
+
+	// Collect the metrics
+	c.neighborsEntriesCount.Collect(ch)
+	c.neighborsIncompleteCount.Collect(ch)
+	c.neighborsExpiredCount.Collect(ch)
+	c.neighborsEntryInfo.Collect(ch)
+	c.neighborsEntryTruncated.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *NeighborsCollector) resetMetrics() {
+	c.neighborsEntriesCount.Reset()
+	c.neighborsIncompleteCount.Reset()
+	c.neighborsExpiredCount.Reset()
+	c.neighborsEntryInfo.Reset()
+	c.neighborsEntryTruncated.Reset()
+}
+
+// neighborEntryIncomplete checks whether a neighbor table entry has not been resolved to a MAC address.
+func neighborEntryIncomplete(entry NeighborEntry) bool {
+	return entry.MACAddress == "" || strings.Contains(strings.ToLower(entry.MACAddress), "incomplete")
+}
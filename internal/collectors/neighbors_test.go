@@ -0,0 +1,49 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+func TestNeighborsCollectorCollectWithTarget(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/diagnostics/arp_table": `[
+			{"ip_address":"192.168.1.10","mac_address":"00:11:22:33:44:55","hostname":"nas","interface":"lan","expires":"Expires in 1199 seconds"},
+			{"ip_address":"192.168.1.11","mac_address":"(incomplete)","hostname":"","interface":"lan","expires":"Expired"},
+			{"ip_address":"10.0.10.5","mac_address":"00:11:22:33:44:66","hostname":"","interface":"opt1","expires":"Permanent"}
+		]`,
+	}
+
+	// Per-entry metrics should be disabled by default
+	target := newTestTarget(t, responses)
+	samples := collectTestSamples(t, NewNeighborsCollector(), target)
+
+	if sample, ok := findTestSample(samples, "pfsense_neighbors_entries_count", map[string]string{"interface": "lan"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 ARP entries on lan, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_neighbors_incomplete_entries_count", map[string]string{"interface": "lan"}); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 incomplete ARP entry on lan, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_neighbors_expired_entries_count", map[string]string{"interface": "opt1"}); !ok || sample.value != 0 {
+		t.Errorf("Expected 0 expired ARP entries on opt1, got %v", sample.value)
+	}
+	if count := countTestSamples(samples, "pfsense_neighbors_entry_info"); count != 0 {
+		t.Errorf("Expected no entry info metrics, got %d", count)
+	}
+
+	// Per-entry metrics should be capped at max_entries
+	target = newTestTarget(t, responses)
+	target.Neighbors = utils.NeighborsOptions{IncludeEntries: true, MaxEntries: 2}
+	samples = collectTestSamples(t, NewNeighborsCollector(), target)
+
+	if count := countTestSamples(samples, "pfsense_neighbors_entry_info"); count != 2 {
+		t.Errorf("Expected 2 entry info metrics, got %d", count)
+	}
+	if _, ok := findTestSample(samples, "pfsense_neighbors_entry_info", map[string]string{"ip_address": "192.168.1.10", "mac_address": "00:11:22:33:44:55", "hostname": "nas"}); !ok {
+		t.Error("Expected entry info metric for 192.168.1.10")
+	}
+	if sample, ok := findTestSample(samples, "pfsense_neighbors_entry_info_truncated", nil); !ok || sample.value != 1 {
+		t.Errorf("Expected entry info metrics to be truncated, got %v", sample.value)
+	}
+}
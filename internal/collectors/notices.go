@@ -0,0 +1,171 @@
+package collectors
+
+import (
+	"regexp"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// noticesRebootRequiredPattern matches the wording pfSense and its packages use for notices asking for a reboot
+// (e.g. "A reboot is required", "requires a reboot" or "must be rebooted"), but not notices that merely mention one,
+// such as an unexpected or scheduled reboot.
+var noticesRebootRequiredPattern = regexp.MustCompile(`(?i)\breboot (is )?(required|needed)\b|\brequires? a( system)? reboot\b|\bmust be rebooted\b`)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewNoticesCollector())
+}
+
+// NoticesCollector collects metrics about pending GUI notices, crash reports and the cron job inventory.
+type NoticesCollector struct {
+	noticesCount           *prometheus.GaugeVec
+	noticesNewestTimestamp *prometheus.GaugeVec
+	noticesRebootRequired  *prometheus.GaugeVec
+	noticesCrashReport     *prometheus.GaugeVec
+	cronJobInfo            *prometheus.GaugeVec
+}
+
+// NoticeStats represents the structure of the GUI notice data returned by the API.
+type NoticeStats struct {
+	ID       string  `json:"id"`
+	Time     float64 `json:"time"`
+	Category string  `json:"category"`
+	Notice   string  `json:"notice"`
+}
+
+// CrashReportStats represents the structure of the crash report data returned by the API.
+type CrashReportStats struct {
+	Files []string `json:"files"`
+}
+
+// CronJobStats represents the structure of the cron job data returned by the API.
+type CronJobStats struct {
+	Minute  string `json:"minute"`
+	Hour    string `json:"hour"`
+	MDay    string `json:"mday"`
+	Month   string `json:"month"`
+	WDay    string `json:"wday"`
+	Who     string `json:"who"`
+	Command string `json:"command"`
+}
+
+// NewNoticesCollector is the constructor
+func NewNoticesCollector() *NoticesCollector {
+	return &NoticesCollector{
+		noticesCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "notices_count",
+				Help: "The number of pending GUI notices by category.",
+			},
+			[]string{"host", "category"},
+		),
+		noticesNewestTimestamp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "notices_newest_timestamp_seconds",
+				Help: "The Unix timestamp of the newest pending GUI notice by category.",
+			},
+			[]string{"host", "category"},
+		),
+		noticesRebootRequired: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "notices_reboot_required",
+				Help: "Whether a pending GUI notice asks for a reboot (1) or not (0).",
+			},
+			[]string{"host"},
+		),
+		noticesCrashReport: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "notices_crash_report_present",
+				Help: "Whether a crash report is waiting to be reviewed (1) or not (0).",
+			},
+			[]string{"host"},
+		),
+		cronJobInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "cron_job_info",
+				Help: "Contains details about the cron job's schedule, user and command.",
+			},
+			[]string{"host", "minute", "hour", "mday", "month", "wday", "who", "command"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *NoticesCollector) Name() string {
+	return "notices"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *NoticesCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.noticesCount.Describe(ch)
+	c.noticesNewestTimestamp.Describe(ch)
+	c.noticesRebootRequired.Describe(ch)
+	c.noticesCrashReport.Describe(ch)
+	c.cronJobInfo.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *NoticesCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each pending notice identified
+	var notices []NoticeStats
+	if err := utils.RequestData(target, "GET", "/api/v2/system/notices", &notices); err != nil {
+		log.Error("notices", "failed to fetch notices from host %s: %s", target.Host, err.Error())
+	} else {
+		rebootRequired := false
+		newest := make(map[string]float64)
+		for _, notice := range notices {
+			category := notice.Category
+			if category == "" {
+				category = "General"
+			}
+			c.noticesCount.WithLabelValues(target.Host, category).Inc()
+			newest[category] = max(newest[category], notice.Time)
+			if noticesRebootRequiredPattern.MatchString(notice.Notice) {
+				rebootRequired = true
+			}
+		}
+		for category, timestamp := range newest {
+			c.noticesNewestTimestamp.WithLabelValues(target.Host, category).Set(timestamp)
+		}
+		c.noticesRebootRequired.WithLabelValues(target.Host).Set(utils.BoolToFloat64(rebootRequired))
+	}
+
+	// Check whether a crash report is present
+	var crash CrashReportStats
+	if err := utils.RequestData(target, "GET", "/api/v2/diagnostics/crash_report", &crash); err != nil {
+		log.Error("notices", "failed to fetch crash report from host %s: %s", target.Host, err.Error())
+	} else {
+		c.noticesCrashReport.WithLabelValues(target.Host).Set(utils.BoolToFloat64(len(crash.Files) > 0))
+	}
+
+	// Extract metrics for each cron job identified
+	var jobs []CronJobStats
+	if err := utils.RequestData(target, "GET", "/api/v2/services/cron/jobs", &jobs); err != nil {
+		log.Error("notices", "failed to fetch cron jobs from host %s: %s", target.Host, err.Error())
+	}
+	for _, job := range jobs {
+		c.cronJobInfo.WithLabelValues(target.Host, job.Minute, job.Hour, job.MDay, job.Month, job.WDay, job.Who, job.Command).Set(1)
+	}
+
+	// Collect the metrics
+	c.noticesCount.Collect(ch)
+	c.noticesNewestTimestamp.Collect(ch)
+	c.noticesRebootRequired.Collect(ch)
+	c.noticesCrashReport.Collect(ch)
+	c.cronJobInfo.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *NoticesCollector) resetMetrics() {
+	c.noticesCount.Reset()
+	c.noticesNewestTimestamp.Reset()
+	c.noticesRebootRequired.Reset()
+	c.noticesCrashReport.Reset()
+	c.cronJobInfo.Reset()
+}
@@ -0,0 +1,81 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestNoticesCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/notices": `[
+			{"id":"1700000000","time":1700000000,"category":"Package","notice":"Installation of package pfBlockerNG failed."},
+			{"id":"1700000500","time":1700000500,"category":"Package","notice":"Package suricata requires a reboot."},
+			{"id":"1700000100","time":1700000100,"category":"","notice":"Certificate is expiring soon."}
+		]`,
+		"/api/v2/diagnostics/crash_report": `{"files":["/var/crash/textdump.tar.0"]}`,
+		"/api/v2/services/cron/jobs": `[
+			{"minute":"*/60","hour":"*","mday":"*","month":"*","wday":"*","who":"root","command":"/usr/bin/nice -n20 newsyslog"}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewNoticesCollector(), target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_notices_count", map[string]string{"category": "Package"}, 2},
+		{"pfsense_notices_count", map[string]string{"category": "General"}, 1},
+		{"pfsense_notices_newest_timestamp_seconds", map[string]string{"category": "Package"}, 1700000500},
+		{"pfsense_notices_reboot_required", nil, 1},
+		{"pfsense_notices_crash_report_present", nil, 1},
+		{"pfsense_cron_job_info", map[string]string{"minute": "*/60", "who": "root", "command": "/usr/bin/nice -n20 newsyslog"}, 1},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+}
+
+func TestNoticesCollectorCollectWithTargetEmpty(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/notices":           `[]`,
+		"/api/v2/diagnostics/crash_report": `{"files":[]}`,
+	})
+
+	samples := collectTestSamples(t, NewNoticesCollector(), target)
+
+	if sample, ok := findTestSample(samples, "pfsense_notices_reboot_required", nil); !ok || sample.value != 0 {
+		t.Errorf("Expected no reboot to be required, got %v (found: %v)", sample.value, ok)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_notices_crash_report_present", nil); !ok || sample.value != 0 {
+		t.Errorf("Expected no crash report, got %v (found: %v)", sample.value, ok)
+	}
+	if count := countTestSamples(samples, "pfsense_notices_count"); count != 0 {
+		t.Errorf("Expected no notice counts, got %d", count)
+	}
+}
+
+func TestNoticesRebootRequiredPattern(t *testing.T) {
+	tests := []struct {
+		notice   string
+		expected bool
+	}{
+		{"Package suricata requires a reboot.", true},
+		{"A reboot is required for the changes to take effect.", true},
+		{"Reboot required to finish the upgrade.", true},
+		{"The system must be rebooted to apply the new kernel.", true},
+		{"The system rebooted unexpectedly.", false},
+		{"Reboot scheduled by cron job.", false},
+		{"Certificate is expiring soon.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.notice, func(t *testing.T) {
+			if result := noticesRebootRequiredPattern.MatchString(tt.notice); result != tt.expected {
+				t.Errorf("Expected %v for notice %q, got %v", tt.expected, tt.notice, result)
+			}
+		})
+	}
+}
@@ -2,6 +2,11 @@ package collectors
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pfrest/pfsense_exporter/internal/log"
 	"github.com/pfrest/pfsense_exporter/internal/registry"
@@ -9,6 +14,37 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// packageServices maps the lowercase short names of packages to the names of the services they install, as listed
+// by the /api/v2/status/services endpoint. Packages without services (e.g. cron, whose name matches the base
+// system's cron service) are intentionally left out.
+var packageServices = map[string][]string{
+	"apcupsd":           {"apcupsd"},
+	"avahi":             {"avahi"},
+	"bind":              {"named"},
+	"frr":               {"frr"},
+	"haproxy":           {"haproxy"},
+	"haproxy-devel":     {"haproxy"},
+	"lldpd":             {"lldpd"},
+	"ntopng":            {"ntopng"},
+	"nut":               {"nut"},
+	"openbgpd":          {"bgpd"},
+	"pfblockerng":       {"pfb_dnsbl", "pfb_filter"},
+	"pfblockerng-devel": {"pfb_dnsbl", "pfb_filter"},
+	"snort":             {"snort"},
+	"squid":             {"squid", "clamd", "c-icap"},
+	"suricata":          {"suricata"},
+	"tailscale":         {"tailscale"},
+	"wireguard":         {"wireguard"},
+	"zabbix-agent":      {"zabbix_agentd"},
+}
+
+// packageCacheTTL is how long the installed packages of a target are cached by collectors for optional packages.
+const packageCacheTTL = 5 * time.Minute
+
+// installedPackages caches the installed packages of each target for collectors of optional packages (e.g. Suricata),
+// so they can skip targets without the package without querying the package list every scrape.
+var installedPackages = newPackageCache(packageCacheTTL)
+
 // init ensures the collector is automatically added to the registry.
 func init() {
 	registry.Register(NewPackageCollector())
@@ -16,7 +52,10 @@ func init() {
 
 // PackageCollector collects metrics about package status.
 type PackageCollector struct {
-	updateAvailable *prometheus.GaugeVec
+	packageInfo        *prometheus.GaugeVec
+	updateAvailable    *prometheus.GaugeVec
+	packagesInstalled  *prometheus.GaugeVec
+	packageServiceDown *prometheus.GaugeVec
 }
 
 // PackageStats represents the structure of the package status data returned by the API.
@@ -31,12 +70,33 @@ type PackageStats struct {
 // NewPackageCollector is the constructor
 func NewPackageCollector() *PackageCollector {
 	return &PackageCollector{
+		packageInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "package_info",
+				Help: "Contains details about the installed package's short name and version.",
+			},
+			[]string{"host", "name", "shortname", "installed_version"},
+		),
 		updateAvailable: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: registry.MetricsPrefix + "package_update_available",
 				Help: "Whether an update is available for the package (1) or not (0).",
 			},
-			[]string{"host", "name", "shortname", "installed_version", "latest_version"},
+			[]string{"host", "name"},
+		),
+		packagesInstalled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "packages_installed_count",
+				Help: "The number of installed packages.",
+			},
+			[]string{"host"},
+		),
+		packageServiceDown: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "package_service_down",
+				Help: "Whether an enabled service of the installed package is not running (1) or not (0).",
+			},
+			[]string{"host", "name"},
 		),
 	}
 }
@@ -48,7 +108,10 @@ func (c *PackageCollector) Name() string {
 
 // Describe sends the metric descriptions to the channel.
 func (c *PackageCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.packageInfo.Describe(ch)
 	c.updateAvailable.Describe(ch)
+	c.packagesInstalled.Describe(ch)
+	c.packageServiceDown.Describe(ch)
 }
 
 // Collect fetches the stats and sends them to the channel.
@@ -56,35 +119,151 @@ func (c *PackageCollector) CollectWithTarget(ch chan<- prometheus.Metric, target
 	// Collect metrics from the target
 	resp, err := utils.Request(target, "GET", "/api/v2/system/packages")
 	if err != nil {
-		log.Error("packages", "failed to fetch package statuses from host %s: %s", target.Host, err.Error())
+		log.Error("package", "failed to fetch package statuses from host %s: %s", target.Host, err.Error())
 		return
 	}
 	if resp == nil || resp.Data == nil {
-		log.Error("packages", "received nil response from host %s", target.Host)
+		log.Error("package", "received nil response from host %s", target.Host)
 		return
 	}
 
 	// Convert the data to an array of PackageStats
 	var packages []PackageStats
 	if err := json.Unmarshal(resp.Data, &packages); err != nil {
-		log.Error("packages", "failed to unmarshal packages response from host %s: %s", target.Host, err.Error())
+		log.Error("package", "failed to unmarshal packages response from host %s: %s", target.Host, err.Error())
 		return
 	}
 
+	// Share the fetched packages with the collectors of optional packages
+	installedPackages.Update(target, packages)
+
+	// Collect the service statuses to determine whether the packages' services are running
+	var services []ServiceStats
+	if err := utils.RequestData(target, "GET", "/api/v2/status/services", &services); err != nil {
+		log.Error("package", "failed to fetch service statuses from host %s: %s", target.Host, err.Error())
+	}
+
 	// Reset metrics before collecting new data
 	c.resetMetrics()
 
 	// Extract metrics for each package identified
 	for _, pkg := range packages {
 		// Update the metrics
-		c.updateAvailable.WithLabelValues(target.Host, pkg.Name, pkg.Shortname, pkg.InstalledVersion, pkg.LatestVersion).Set(float64(utils.BoolToFloat64(pkg.UpdateAvailable)))
+		c.packageInfo.WithLabelValues(target.Host, pkg.Name, pkg.Shortname, pkg.InstalledVersion).Set(1)
+		c.updateAvailable.WithLabelValues(target.Host, pkg.Name).Set(utils.BoolToFloat64(pkg.UpdateAvailable))
+
+		// Only report the service status for packages with a matching service
+		if down, ok := packageServiceDown(pkg, services); ok {
+			c.packageServiceDown.WithLabelValues(target.Host, pkg.Name).Set(utils.BoolToFloat64(down))
+		}
 	}
+	c.packagesInstalled.WithLabelValues(target.Host).Set(float64(len(packages)))
 
 	// Collect the metrics
+	c.packageInfo.Collect(ch)
 	c.updateAvailable.Collect(ch)
+	c.packagesInstalled.Collect(ch)
+	c.packageServiceDown.Collect(ch)
 }
 
 // resetMetrics resets all metrics in the collector.
 func (c *PackageCollector) resetMetrics() {
+	c.packageInfo.Reset()
 	c.updateAvailable.Reset()
+	c.packagesInstalled.Reset()
+	c.packageServiceDown.Reset()
+}
+
+// packageServiceDown checks whether any enabled service belonging to the package is not running. Services are
+// matched to the package through packageServices. The second return value is false if the package has no known
+// service or none of its services are listed.
+func packageServiceDown(pkg PackageStats, services []ServiceStats) (bool, bool) {
+	names, ok := packageServices[strings.ToLower(pkg.Shortname)]
+	if !ok {
+		return false, false
+	}
+
+	found, down := false, false
+	for _, service := range services {
+		if !slices.Contains(names, service.Name) {
+			continue
+		}
+		found = true
+		if service.Enabled && !service.Status {
+			down = true
+		}
+	}
+	return down, found
+}
+
+// packageCache caches the short names of the packages installed on each target.
+type packageCache struct {
+	ttl     time.Duration
+	entries map[string]*packageCacheEntry
+	mu      sync.Mutex
+}
+
+// packageCacheEntry holds the short names of the packages installed on a target and when they were fetched. Its
+// lock is held while the packages are refreshed, so concurrent lookups for the same target wait for a single fetch.
+type packageCacheEntry struct {
+	shortnames map[string]bool
+	fetched    time.Time
+	mu         sync.Mutex
+}
+
+// newPackageCache is the constructor
+func newPackageCache(ttl time.Duration) *packageCache {
+	return &packageCache{ttl: ttl, entries: make(map[string]*packageCacheEntry)}
+}
+
+// entry returns the cache entry of the target, creating an empty one if the target hasn't been seen yet.
+func (c *packageCache) entry(target *utils.Target) *packageCacheEntry {
+	key := fmt.Sprintf("%s:%d", target.Host, target.Port)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &packageCacheEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// Installed checks whether the package with the given short name is installed on the target, ignoring case. The
+// installed packages are fetched from the target at most once per TTL.
+func (c *packageCache) Installed(target *utils.Target, shortname string) (bool, error) {
+	entry := c.entry(target)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	// Refresh the installed packages from the target if they are missing or expired
+	if entry.shortnames == nil || time.Since(entry.fetched) >= c.ttl {
+		var packages []PackageStats
+		if err := utils.RequestData(target, "GET", "/api/v2/system/packages", &packages); err != nil {
+			return false, err
+		}
+		entry.update(packages)
+	}
+
+	return entry.shortnames[strings.ToLower(shortname)], nil
+}
+
+// Update replaces the cached packages of the target with packages fetched elsewhere (e.g. by the package collector).
+func (c *packageCache) Update(target *utils.Target, packages []PackageStats) {
+	entry := c.entry(target)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	entry.update(packages)
+}
+
+// update replaces the entry's short names with the given packages. The entry's lock must be held.
+func (e *packageCacheEntry) update(packages []PackageStats) {
+	e.shortnames = make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		e.shortnames[strings.ToLower(pkg.Shortname)] = true
+	}
+	e.fetched = time.Now()
 }
@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pfrest/pfsense_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,9 +22,18 @@ func TestNewPackageCollector(t *testing.T) {
 		t.Error("Expected collector to be created")
 	}
 
+	if collector.packageInfo == nil {
+		t.Error("Expected packageInfo metric to be initialized")
+	}
 	if collector.updateAvailable == nil {
 		t.Error("Expected updateAvailable metric to be initialized")
 	}
+	if collector.packagesInstalled == nil {
+		t.Error("Expected packagesInstalled metric to be initialized")
+	}
+	if collector.packageServiceDown == nil {
+		t.Error("Expected packageServiceDown metric to be initialized")
+	}
 }
 
 func TestPackageCollectorName(t *testing.T) {
@@ -45,9 +59,9 @@ func TestPackageCollectorDescribe(t *testing.T) {
 		count++
 	}
 
-	// Should have 1 description
-	if count != 1 {
-		t.Errorf("Expected 1 metric description, got %d", count)
+	// Should have 4 descriptions
+	if count != 4 {
+		t.Errorf("Expected 4 metric descriptions, got %d", count)
 	}
 }
 
@@ -99,6 +113,59 @@ func TestPackageCollectorCollectWithTarget(t *testing.T) {
 	_ = server.URL // Use server URL to avoid unused variable warning
 }
 
+func TestPackageCollectorCollectWithTargetMetrics(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[
+			{"name":"pfSense-pkg-haproxy","shortname":"haproxy","installed_version":"0.63_2","latest_version":"0.63_3","update_available":true},
+			{"name":"pfSense-pkg-suricata","shortname":"suricata","installed_version":"7.0.6","latest_version":"7.0.6","update_available":false},
+			{"name":"pfSense-pkg-Cron","shortname":"cron","installed_version":"0.3.8","latest_version":"0.3.8","update_available":false},
+			{"name":"pfSense-pkg-pfBlockerNG-devel","shortname":"pfBlockerNG-devel","installed_version":"3.2.0_20","latest_version":"3.2.0_20","update_available":false}
+		]`,
+		"/api/v2/status/services": `[
+			{"name":"haproxy","enabled":true,"status":true},
+			{"name":"suricata","enabled":true,"status":false},
+			{"name":"unbound","enabled":true,"status":true},
+			{"name":"cron","enabled":true,"status":false},
+			{"name":"pfb_dnsbl","enabled":true,"status":false},
+			{"name":"pfb_filter","enabled":true,"status":true}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewPackageCollector(), target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_package_info", map[string]string{"name": "pfSense-pkg-haproxy", "shortname": "haproxy", "installed_version": "0.63_2"}, 1},
+		{"pfsense_package_update_available", map[string]string{"name": "pfSense-pkg-haproxy"}, 1},
+		{"pfsense_package_update_available", map[string]string{"name": "pfSense-pkg-Cron"}, 0},
+		{"pfsense_packages_installed_count", nil, 4},
+		{"pfsense_package_service_down", map[string]string{"name": "pfSense-pkg-haproxy"}, 0},
+		{"pfsense_package_service_down", map[string]string{"name": "pfSense-pkg-suricata"}, 1},
+		{"pfsense_package_service_down", map[string]string{"name": "pfSense-pkg-pfBlockerNG-devel"}, 1},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+
+	// Packages without a known service should not report a service status, even if a base service shares their name
+	if _, ok := findTestSample(samples, "pfsense_package_service_down", map[string]string{"name": "pfSense-pkg-Cron"}); ok {
+		t.Error("Expected no service status for a package without services")
+	}
+
+	// The update metric should not carry version labels
+	for _, sample := range samples {
+		if _, ok := sample.labels["latest_version"]; ok {
+			t.Errorf("Expected no latest_version label, got it on %s", sample.name)
+		}
+	}
+}
+
 func TestPackageCollectorCollectWithTargetError(t *testing.T) {
 	// Test with unreachable target to trigger error handling
 	target := &utils.Target{
@@ -149,3 +216,74 @@ func TestPackageStatsStruct(t *testing.T) {
 		t.Error("Expected UpdateAvailable to be true")
 	}
 }
+
+func TestPackageCacheInstalled(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/system/packages": `[{"name":"pfSense-pkg-haproxy","shortname":"haproxy"}]`,
+	}
+	target := newTestTarget(t, responses)
+	cache := newPackageCache(time.Hour)
+
+	if installed, err := cache.Installed(target, "haproxy"); err != nil || !installed {
+		t.Errorf("Expected haproxy to be installed, got %v (err: %v)", installed, err)
+	}
+	if installed, err := cache.Installed(target, "suricata"); err != nil || installed {
+		t.Errorf("Expected suricata not to be installed, got %v (err: %v)", installed, err)
+	}
+
+	// The installed packages should be cached until the TTL expires
+	responses["/api/v2/system/packages"] = `[{"name":"pfSense-pkg-suricata","shortname":"suricata"}]`
+	if installed, _ := cache.Installed(target, "suricata"); installed {
+		t.Error("Expected the cached packages to be used within the TTL")
+	}
+	cache.ttl = 0
+	if installed, _ := cache.Installed(target, "suricata"); !installed {
+		t.Error("Expected the packages to be refreshed after the TTL")
+	}
+
+	// Packages fetched by the package collector should replace the cached packages
+	cache.ttl = time.Hour
+	cache.Update(target, []PackageStats{{Name: "pfSense-pkg-haproxy", Shortname: "haproxy"}})
+	if installed, _ := cache.Installed(target, "suricata"); installed {
+		t.Error("Expected the updated packages to be used")
+	}
+
+	// Errors should be returned rather than treated as not installed
+	unreachable := &utils.Target{Host: "nonexistent.host.invalid.test", Port: 443, Scheme: "https", Timeout: 1}
+	if _, err := cache.Installed(unreachable, "haproxy"); err == nil {
+		t.Error("Expected error for an unreachable target")
+	}
+}
+
+func TestPackageCacheInstalledConcurrent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":200,"status":"ok","data":[{"name":"pfSense-pkg-suricata","shortname":"suricata"}]}`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	target := &utils.Target{Host: serverURL.Hostname(), Port: port, Scheme: serverURL.Scheme, AuthMethod: "basic", Timeout: 30}
+	cache := newPackageCache(time.Hour)
+
+	// Concurrent lookups on a cold cache should share a single fetch
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if installed, err := cache.Installed(target, "suricata"); err != nil || !installed {
+				t.Errorf("Expected suricata to be installed, got %v (err: %v)", installed, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := requests.Load(); count != 1 {
+		t.Errorf("Expected 1 request to fetch the packages, got %d", count)
+	}
+}
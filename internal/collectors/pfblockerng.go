@@ -0,0 +1,153 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pfBlockerNGPackages are the short names of the pfBlockerNG release and development packages.
+var pfBlockerNGPackages = []string{"pfblockerng", "pfblockerng-devel"}
+
+// pfBlockerNGAliasPrefix is the prefix of the firewall aliases (and their pf tables) created by pfBlockerNG.
+const pfBlockerNGAliasPrefix = "pfB_"
+
+// pfBlockerNGDNSBLService is the name of the service serving the DNSBL VIP.
+const pfBlockerNGDNSBLService = "pfb_dnsbl"
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewPFBlockerNGCollector())
+}
+
+// PFBlockerNGCollector collects metrics about pfBlockerNG's IP aliases and DNSBL.
+type PFBlockerNGCollector struct {
+	pfBlockerNGAliasEntries *prometheus.GaugeVec
+	pfBlockerNGAliasSkipped *prometheus.GaugeVec
+	pfBlockerNGDNSBLVIPUp   *prometheus.GaugeVec
+}
+
+// FirewallAliasStats represents the structure of the firewall alias data returned by the API.
+type FirewallAliasStats struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Descr string `json:"descr"`
+}
+
+// NewPFBlockerNGCollector is the constructor
+func NewPFBlockerNGCollector() *PFBlockerNGCollector {
+	return &PFBlockerNGCollector{
+		pfBlockerNGAliasEntries: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "pfblockerng_alias_entries_count",
+				Help: "The number of entries in the pf table of the pfBlockerNG alias.",
+			},
+			[]string{"host", "alias"},
+		),
+		pfBlockerNGAliasSkipped: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "pfblockerng_aliases_skipped_count",
+				Help: "The number of pfBlockerNG aliases whose pf tables were not fetched due to the configured max_aliases.",
+			},
+			[]string{"host"},
+		),
+		pfBlockerNGDNSBLVIPUp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "pfblockerng_dnsbl_vip_up",
+				Help: "Whether the pfBlockerNG DNSBL VIP web server is running (1) or not (0).",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *PFBlockerNGCollector) Name() string {
+	return "pfblockerng"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *PFBlockerNGCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.pfBlockerNGAliasEntries.Describe(ch)
+	c.pfBlockerNGAliasSkipped.Describe(ch)
+	c.pfBlockerNGDNSBLVIPUp.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *PFBlockerNGCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Skip targets without the pfBlockerNG package installed
+	installed := false
+	for _, pkg := range pfBlockerNGPackages {
+		ok, err := installedPackages.Installed(target, pkg)
+		if err != nil {
+			log.Error("pfblockerng", "failed to fetch installed packages from host %s: %s", target.Host, err.Error())
+			return
+		}
+		installed = installed || ok
+	}
+	if !installed {
+		log.Debug("pfblockerng", "skipping host %s without the pfBlockerNG package installed", target.Host)
+		return
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Count the entries in the pf table of each pfBlockerNG alias, up to the maximum number of aliases. The API only
+	// returns one table per request, so this bounds the number of requests made to the target.
+	var aliases []FirewallAliasStats
+	if err := utils.RequestData(target, "GET", "/api/v2/firewall/aliases", &aliases); err != nil {
+		log.Error("pfblockerng", "failed to fetch firewall aliases from host %s: %s", target.Host, err.Error())
+	} else {
+		c.pfBlockerNGAliasSkipped.WithLabelValues(target.Host).Set(0)
+	}
+	maxAliases := target.PFBlockerNG.MaxAliases
+	if maxAliases < 1 {
+		// Fall back to the default limit for targets that haven't been validated
+		maxAliases = 50
+	}
+	fetched := 0
+	for _, alias := range aliases {
+		if !strings.HasPrefix(alias.Name, pfBlockerNGAliasPrefix) {
+			continue
+		}
+		if fetched >= maxAliases {
+			c.pfBlockerNGAliasSkipped.WithLabelValues(target.Host).Inc()
+			continue
+		}
+		fetched++
+
+		entries, err := fetchTableEntries(target, alias.Name)
+		if err != nil {
+			log.Error("pfblockerng", "failed to fetch %s table from host %s: %s", alias.Name, target.Host, err.Error())
+			continue
+		}
+		c.pfBlockerNGAliasEntries.WithLabelValues(target.Host, alias.Name).Set(float64(len(entries)))
+	}
+
+	// Determine whether the DNSBL VIP web server is running
+	var services []ServiceStats
+	if err := utils.RequestData(target, "GET", "/api/v2/status/services", &services); err != nil {
+		log.Error("pfblockerng", "failed to fetch service statuses from host %s: %s", target.Host, err.Error())
+	}
+	for _, service := range services {
+		if service.Name == pfBlockerNGDNSBLService && service.Enabled {
+			c.pfBlockerNGDNSBLVIPUp.WithLabelValues(target.Host).Set(utils.BoolToFloat64(service.Status))
+		}
+	}
+
+	// Collect the metrics
+	c.pfBlockerNGAliasEntries.Collect(ch)
+	c.pfBlockerNGAliasSkipped.Collect(ch)
+	c.pfBlockerNGDNSBLVIPUp.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *PFBlockerNGCollector) resetMetrics() {
+	c.pfBlockerNGAliasEntries.Reset()
+	c.pfBlockerNGAliasSkipped.Reset()
+	c.pfBlockerNGDNSBLVIPUp.Reset()
+}
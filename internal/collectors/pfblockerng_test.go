@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+// newPFBlockerNGTestTarget creates a target for a test server serving the responses by path and the pf tables by
+// their id, since the tables are requested on the same path. The returned counter tracks the table requests.
+func newPFBlockerNGTestTarget(t *testing.T, responses map[string]string, tables map[string]string) (*utils.Target, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data, ok := responses[r.URL.Path]
+		if r.URL.Path == "/api/v2/diagnostics/table" {
+			requests.Add(1)
+			data, ok = tables[r.URL.Query().Get("id")]
+			data = fmt.Sprintf(`{"id":"%s","entries":%s}`, r.URL.Query().Get("id"), data)
+		}
+		if !ok {
+			fmt.Fprint(w, `{"code": 404, "status": "not found", "message": "Endpoint not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"code": 200, "status": "ok", "data": %s}`, data)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	target := &utils.Target{
+		Host:       serverURL.Hostname(),
+		Port:       port,
+		Scheme:     serverURL.Scheme,
+		Username:   "test",
+		Password:   "test",
+		AuthMethod: "basic",
+		Timeout:    30,
+	}
+	return target, &requests
+}
+
+func TestPFBlockerNGCollectorCollectWithTarget(t *testing.T) {
+	target, _ := newPFBlockerNGTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[{"name":"pfSense-pkg-pfBlockerNG-devel","shortname":"pfBlockerNG-devel"}]`,
+		"/api/v2/firewall/aliases": `[
+			{"name":"pfB_PRI1_v4","type":"urltable","descr":"pfBlockerNG auto Alias"},
+			{"name":"pfB_Tor_v4","type":"urltable","descr":"pfBlockerNG auto Alias"},
+			{"name":"LAN_Servers","type":"host","descr":"Servers"}
+		]`,
+		"/api/v2/status/services": `[{"name":"pfb_dnsbl","enabled":true,"status":false}]`,
+	}, map[string]string{
+		"pfB_PRI1_v4": `["1.2.3.4","5.6.7.0/24","9.9.9.9"]`,
+		"pfB_Tor_v4":  `["10.0.0.1"]`,
+	})
+
+	samples := collectTestSamples(t, NewPFBlockerNGCollector(), target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_pfblockerng_alias_entries_count", map[string]string{"alias": "pfB_PRI1_v4"}, 3},
+		{"pfsense_pfblockerng_alias_entries_count", map[string]string{"alias": "pfB_Tor_v4"}, 1},
+		{"pfsense_pfblockerng_aliases_skipped_count", nil, 0},
+		{"pfsense_pfblockerng_dnsbl_vip_up", nil, 0},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+
+	// Aliases not created by pfBlockerNG should be skipped
+	if _, ok := findTestSample(samples, "pfsense_pfblockerng_alias_entries_count", map[string]string{"alias": "LAN_Servers"}); ok {
+		t.Error("Expected no entry count for an alias not created by pfBlockerNG")
+	}
+}
+
+func TestPFBlockerNGCollectorCollectWithTargetMaxAliases(t *testing.T) {
+	aliases := make([]string, 0, 15)
+	tables := make(map[string]string, 15)
+	for i := range 15 {
+		name := fmt.Sprintf("pfB_Feed%d_v4", i)
+		aliases = append(aliases, fmt.Sprintf(`{"name":"%s","type":"urltable"}`, name))
+		tables[name] = `["1.2.3.4"]`
+	}
+	target, requests := newPFBlockerNGTestTarget(t, map[string]string{
+		"/api/v2/system/packages":  `[{"name":"pfSense-pkg-pfBlockerNG","shortname":"pfBlockerNG"}]`,
+		"/api/v2/firewall/aliases": "[" + strings.Join(aliases, ",") + "]",
+	}, tables)
+	target.PFBlockerNG.MaxAliases = 5
+
+	// Only the first aliases up to the maximum should be fetched, and the remaining aliases counted as skipped
+	samples := collectTestSamples(t, NewPFBlockerNGCollector(), target)
+	if count := countTestSamples(samples, "pfsense_pfblockerng_alias_entries_count"); count != 5 {
+		t.Errorf("Expected 5 alias entry counts, got %d", count)
+	}
+	if count := requests.Load(); count != 5 {
+		t.Errorf("Expected 5 table requests, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_pfblockerng_aliases_skipped_count", nil); !ok || sample.value != 10 {
+		t.Errorf("Expected 10 skipped aliases, got %v (found: %v)", sample.value, ok)
+	}
+}
+
+func TestPFBlockerNGCollectorCollectWithTargetNotInstalled(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/system/packages": `[]`,
+	})
+
+	// Targets without the package should be skipped without metrics
+	samples := collectTestSamples(t, NewPFBlockerNGCollector(), target)
+	if len(samples) != 0 {
+		t.Errorf("Expected no metrics without the package installed, got %d", len(samples))
+	}
+}
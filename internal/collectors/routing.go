@@ -0,0 +1,171 @@
+package collectors
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewRoutingCollector())
+}
+
+// RoutingCollector collects metrics about the kernel routing table and configured static routes.
+type RoutingCollector struct {
+	routingTableRoutesCount            *prometheus.GaugeVec
+	routingStaticRouteInfo             *prometheus.GaugeVec
+	routingStaticRouteGatewayReachable *prometheus.GaugeVec
+	routingStaticRoutesGatewayDown     *prometheus.GaugeVec
+}
+
+// RoutingTableEntry represents the structure of a kernel routing table entry returned by the API.
+type RoutingTableEntry struct {
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway"`
+	Flags       string `json:"flags"`
+	NetIf       string `json:"netif"`
+}
+
+// StaticRouteStats represents the structure of the static route data returned by the API.
+type StaticRouteStats struct {
+	Network  string `json:"network"`
+	Gateway  string `json:"gateway"`
+	Descr    string `json:"descr"`
+	Disabled bool   `json:"disabled"`
+}
+
+// NewRoutingCollector is the constructor
+func NewRoutingCollector() *RoutingCollector {
+	return &RoutingCollector{
+		routingTableRoutesCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "routing_table_routes_count",
+				Help: "The number of routes in the kernel routing table by address family, interface and flags.",
+			},
+			[]string{"host", "family", "interface", "flags"},
+		),
+		routingStaticRouteInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "routing_static_route_info",
+				Help: "Contains details about the static route's network, gateway and description.",
+			},
+			[]string{"host", "network", "gateway", "descr", "disabled"},
+		),
+		routingStaticRouteGatewayReachable: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "routing_static_route_gateway_reachable",
+				Help: "Whether the static route's gateway is reachable (1) or not (0).",
+			},
+			[]string{"host", "network", "gateway"},
+		),
+		routingStaticRoutesGatewayDown: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "routing_static_routes_gateway_down_count",
+				Help: "The number of enabled static routes whose gateway is not reachable.",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *RoutingCollector) Name() string {
+	return "routing"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *RoutingCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.routingTableRoutesCount.Describe(ch)
+	c.routingStaticRouteInfo.Describe(ch)
+	c.routingStaticRouteGatewayReachable.Describe(ch)
+	c.routingStaticRoutesGatewayDown.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *RoutingCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Count the routes in the kernel routing table
+	var routes []RoutingTableEntry
+	if err := utils.RequestData(target, "GET", "/api/v2/diagnostics/routing_table", &routes); err != nil {
+		log.Error("routing", "failed to fetch routing table from host %s: %s", target.Host, err.Error())
+	}
+	for _, route := range routes {
+		c.routingTableRoutesCount.WithLabelValues(target.Host, routingAddressFamily(route), route.NetIf, route.Flags).Inc()
+	}
+
+	// Extract metrics for each static route identified
+	var staticRoutes []StaticRouteStats
+	if err := utils.RequestData(target, "GET", "/api/v2/routing/static_routes", &staticRoutes); err != nil {
+		log.Error("routing", "failed to fetch static routes from host %s: %s", target.Host, err.Error())
+	}
+	for _, route := range staticRoutes {
+		c.routingStaticRouteInfo.WithLabelValues(target.Host, route.Network, route.Gateway, route.Descr, strconv.FormatBool(route.Disabled)).Set(1)
+	}
+
+	// Determine whether each static route's gateway is reachable from the live gateway statuses
+	if len(staticRoutes) > 0 {
+		var gateways []GatewayStats
+		if err := utils.RequestData(target, "GET", "/api/v2/status/gateways", &gateways); err != nil {
+			log.Error("routing", "failed to fetch gateway statuses from host %s: %s", target.Host, err.Error())
+		} else {
+			statuses := make(map[string]GatewayStats, len(gateways))
+			for _, gw := range gateways {
+				statuses[gw.Name] = gw
+			}
+
+			down := 0
+			for _, route := range staticRoutes {
+				reachable := routingGatewayReachable(statuses, route.Gateway)
+				if !reachable && !route.Disabled {
+					down++
+				}
+				c.routingStaticRouteGatewayReachable.WithLabelValues(target.Host, route.Network, route.Gateway).Set(utils.BoolToFloat64(reachable))
+			}
+			c.routingStaticRoutesGatewayDown.WithLabelValues(target.Host).Set(float64(down))
+		}
+	}
+
+	// Collect the metrics
+	c.routingTableRoutesCount.Collect(ch)
+	c.routingStaticRouteInfo.Collect(ch)
+	c.routingStaticRouteGatewayReachable.Collect(ch)
+	c.routingStaticRoutesGatewayDown.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *RoutingCollector) resetMetrics() {
+	c.routingTableRoutesCount.Reset()
+	c.routingStaticRouteInfo.Reset()
+	c.routingStaticRouteGatewayReachable.Reset()
+	c.routingStaticRoutesGatewayDown.Reset()
+}
+
+// routingAddressFamily determines the address family (inet or inet6) of a routing table entry. The default
+// route has no address in its destination, so the gateway is used instead.
+func routingAddressFamily(route RoutingTableEntry) string {
+	address := route.Destination
+	if address == "default" {
+		address = route.Gateway
+	}
+	if strings.Contains(address, ":") {
+		return "inet6"
+	}
+	return "inet"
+}
+
+// routingGatewayReachable checks whether a gateway is online and hasn't been marked down. Gateways without a
+// status (e.g. removed gateways) are considered unreachable.
+func routingGatewayReachable(statuses map[string]GatewayStats, name string) bool {
+	gw, ok := statuses[name]
+	if !ok {
+		return false
+	}
+	return gatewayGroupMemberUsable(gw, "down")
+}
@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"testing"
+)
+
+func TestRoutingCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/diagnostics/routing_table": `[
+			{"destination":"default","gateway":"203.0.113.1","flags":"UGS","netif":"igb0"},
+			{"destination":"10.0.0.0/24","gateway":"10.0.1.1","flags":"UGS","netif":"igb1"},
+			{"destination":"10.0.2.0/24","gateway":"10.0.1.1","flags":"UGS","netif":"igb1"},
+			{"destination":"default","gateway":"2001:db8::1","flags":"UGS","netif":"igb0"}
+		]`,
+		"/api/v2/routing/static_routes": `[
+			{"network":"10.0.0.0/24","gateway":"LAN_GW","descr":"Branch"},
+			{"network":"10.0.2.0/24","gateway":"VPN_GW","descr":"VPN"},
+			{"network":"10.0.3.0/24","gateway":"OLD_GW","descr":"Old","disabled":true}
+		]`,
+		"/api/v2/status/gateways": `[
+			{"name":"LAN_GW","status":"online","substatus":"none"},
+			{"name":"VPN_GW","status":"down","substatus":"highloss"}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewRoutingCollector(), target)
+
+	// Verify routes are counted by family, interface and flags
+	if sample, ok := findTestSample(samples, "pfsense_routing_table_routes_count", map[string]string{"family": "inet", "interface": "igb1", "flags": "UGS"}); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 inet routes on igb1, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_routing_table_routes_count", map[string]string{"family": "inet6", "interface": "igb0"}); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 inet6 route on igb0, got %v", sample.value)
+	}
+
+	// Verify static route reachability
+	if count := countTestSamples(samples, "pfsense_routing_static_route_info"); count != 3 {
+		t.Errorf("Expected 3 static route info metrics, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_routing_static_route_gateway_reachable", map[string]string{"network": "10.0.0.0/24"}); !ok || sample.value != 1 {
+		t.Errorf("Expected LAN_GW to be reachable, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_routing_static_route_gateway_reachable", map[string]string{"network": "10.0.2.0/24"}); !ok || sample.value != 0 {
+		t.Errorf("Expected VPN_GW to be unreachable, got %v", sample.value)
+	}
+
+	// Disabled routes should not be counted as down
+	if sample, ok := findTestSample(samples, "pfsense_routing_static_routes_gateway_down_count", nil); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 static route with its gateway down, got %v", sample.value)
+	}
+}
+
+func TestRoutingAddressFamily(t *testing.T) {
+	tests := []struct {
+		route    RoutingTableEntry
+		expected string
+	}{
+		{RoutingTableEntry{Destination: "10.0.0.0/24", Gateway: "link#1"}, "inet"},
+		{RoutingTableEntry{Destination: "2001:db8::/64", Gateway: "link#1"}, "inet6"},
+		{RoutingTableEntry{Destination: "default", Gateway: "203.0.113.1"}, "inet"},
+		{RoutingTableEntry{Destination: "default", Gateway: "fe80::1%igb0"}, "inet6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route.Destination+"_"+tt.route.Gateway, func(t *testing.T) {
+			if result := routingAddressFamily(tt.route); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// systemLogSeverities maps syslog severity levels to the value of the severity label.
+var systemLogSeverities = []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"}
+
+// systemLogPriority matches the syslog priority some log formats prefix lines with, e.g. '<134>'.
+var systemLogPriority = regexp.MustCompile(`^<(\d{1,3})>`)
+
+// systemLogSeverityKeywords determines the severity of lines without a syslog priority from keywords, in order.
+var systemLogSeverityKeywords = []struct {
+	severity string
+	pattern  *regexp.Regexp
+}{
+	{"critical", regexp.MustCompile(`(?i)\b(panic|fatal|critical)\b`)},
+	{"error", regexp.MustCompile(`(?i)\berror\b`)},
+	{"warning", regexp.MustCompile(`(?i)\bwarn(ing)?\b`)},
+}
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewSystemLogCollector())
+}
+
+// SystemLogCollector collects metrics about the lines logged to the system, gateway and DHCP logs.
+type SystemLogCollector struct {
+	logLines           *prometheus.Desc
+	logLinesBySeverity *prometheus.Desc
+	cursor             *logCursor
+	classes            *logTotals[systemLogKey]
+	severities         *logTotals[systemLogKey]
+}
+
+// systemLogKey identifies the log and class or severity log lines are counted by.
+type systemLogKey struct {
+	log   string
+	value string
+}
+
+// NewSystemLogCollector is the constructor
+func NewSystemLogCollector() *SystemLogCollector {
+	return &SystemLogCollector{
+		logLines: prometheus.NewDesc(
+			registry.MetricsPrefix+"log_lines_total",
+			"The number of log lines matching the configured class.",
+			[]string{"host", "log", "class"},
+			nil,
+		),
+		logLinesBySeverity: prometheus.NewDesc(
+			registry.MetricsPrefix+"log_lines_by_severity_total",
+			"The number of log lines by severity.",
+			[]string{"host", "log", "severity"},
+			nil,
+		),
+		cursor:     newLogCursor(),
+		classes:    newLogTotals[systemLogKey](),
+		severities: newLogTotals[systemLogKey](),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *SystemLogCollector) Name() string {
+	return "system_log"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *SystemLogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.logLines
+	ch <- c.logLinesBySeverity
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *SystemLogCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Count the lines logged to each log since the previous scrape
+	classes := make(map[systemLogKey]float64)
+	severities := make(map[systemLogKey]float64)
+	for _, name := range target.SystemLog.Logs {
+		lines, err := fetchLogLines(target, name)
+		if err != nil {
+			log.Error("system_log", "failed to fetch %s log from host %s: %s", name, target.Host, err.Error())
+			continue
+		}
+
+		// Initialize the counters so classes without matches are still reported
+		for _, class := range target.SystemLog.Classes {
+			classes[systemLogKey{name, class.Name}] += 0
+		}
+
+		for _, line := range c.cursor.Advance(target.Host+"/"+name, lines) {
+			severities[systemLogKey{name, systemLogSeverity(line)}]++
+			for _, class := range target.SystemLog.Classes {
+				if class.Regexp != nil && class.Regexp.MatchString(line) {
+					classes[systemLogKey{name, class.Name}]++
+				}
+			}
+		}
+	}
+
+	// Emit the accumulated totals as counters
+	for key, total := range c.classes.Add(target.Host, classes) {
+		ch <- prometheus.MustNewConstMetric(c.logLines, prometheus.CounterValue, total, target.Host, key.log, key.value)
+	}
+	for key, total := range c.severities.Add(target.Host, severities) {
+		ch <- prometheus.MustNewConstMetric(c.logLinesBySeverity, prometheus.CounterValue, total, target.Host, key.log, key.value)
+	}
+}
+
+// systemLogSeverity determines the severity of a log line from its syslog priority if present, falling back to
+// keywords in the message. Lines without a priority or keyword are considered info.
+func systemLogSeverity(line string) string {
+	if match := systemLogPriority.FindStringSubmatch(line); match != nil {
+		if priority, err := strconv.Atoi(match[1]); err == nil {
+			return systemLogSeverities[priority%8]
+		}
+	}
+	for _, keyword := range systemLogSeverityKeywords {
+		if keyword.pattern.MatchString(line) {
+			return keyword.severity
+		}
+	}
+	return "info"
+}
@@ -0,0 +1,85 @@
+package collectors
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+)
+
+func TestSystemLogCollectorCollectWithTarget(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/status/logs/system": `[{"text":"Oct 18 12:00:00 pfSense syslogd: restart"}]`,
+		"/api/v2/status/logs/dhcp":   `[]`,
+	}
+	target := newTestTarget(t, responses)
+	target.SystemLog = utils.SystemLogOptions{
+		Logs: []string{"system", "dhcp", "gateways"},
+		Classes: []utils.LogClass{
+			{Name: "mbuf_exhausted", Regexp: regexp.MustCompile(`nmbclusters limit reached`)},
+			{Name: "arp_moved", Regexp: regexp.MustCompile(`arp: .+ moved from`)},
+		},
+	}
+	collector := NewSystemLogCollector()
+
+	// The first scrape should only position the cursors
+	samples := collectTestSamples(t, collector, target)
+	if sample, ok := findTestSample(samples, "pfsense_log_lines_total", map[string]string{"log": "system", "class": "arp_moved"}); !ok || sample.value != 0 {
+		t.Errorf("Expected arp_moved class to be reported as 0 on first scrape, got %v (found: %v)", sample.value, ok)
+	}
+
+	// Lines logged after the first scrape should be counted
+	responses["/api/v2/status/logs/system"] = `[
+		{"text":"Oct 18 12:00:00 pfSense syslogd: restart"},
+		{"text":"Oct 18 12:00:01 pfSense kernel: [zone: mbuf_cluster] kern.ipc.nmbclusters limit reached"},
+		{"text":"Oct 18 12:00:02 pfSense kernel: arp: 192.168.1.5 moved from 00:11:22:33:44:55 to 00:11:22:33:44:66 on igb0"},
+		{"text":"Oct 18 12:00:03 pfSense kernel: arp: 192.168.1.6 moved from 00:11:22:33:44:77 to 00:11:22:33:44:88 on igb0"},
+		{"text":"Oct 18 12:00:04 pfSense php-fpm[123]: PHP ERROR: Type: 1"}
+	]`
+	responses["/api/v2/status/logs/dhcp"] = `[{"text":"<131>Oct 18 12:00:05 pfSense dhcpd: no free leases"}]`
+	samples = collectTestSamples(t, collector, target)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{"pfsense_log_lines_total", map[string]string{"log": "system", "class": "mbuf_exhausted"}, 1},
+		{"pfsense_log_lines_total", map[string]string{"log": "system", "class": "arp_moved"}, 2},
+		{"pfsense_log_lines_total", map[string]string{"log": "dhcp", "class": "arp_moved"}, 0},
+		{"pfsense_log_lines_by_severity_total", map[string]string{"log": "system", "severity": "info"}, 3},
+		{"pfsense_log_lines_by_severity_total", map[string]string{"log": "system", "severity": "error"}, 1},
+		{"pfsense_log_lines_by_severity_total", map[string]string{"log": "dhcp", "severity": "error"}, 1},
+	}
+	for _, tt := range tests {
+		sample, ok := findTestSample(samples, tt.name, tt.labels)
+		if !ok || sample.value != tt.value {
+			t.Errorf("Expected %s %v to be %v, got %v (found: %v)", tt.name, tt.labels, tt.value, sample.value, ok)
+		}
+	}
+
+	// Logs that failed to fetch should not be reported
+	if _, ok := findTestSample(samples, "pfsense_log_lines_total", map[string]string{"log": "gateways"}); ok {
+		t.Error("Expected no metrics for the gateways log that failed to fetch")
+	}
+}
+
+func TestSystemLogSeverity(t *testing.T) {
+	tests := []struct {
+		line     string
+		severity string
+	}{
+		{"<134>Oct 18 12:00:00 pfSense filterlog: 5,,,", "info"},
+		{"<11>Oct 18 12:00:00 pfSense php: error", "error"},
+		{"Oct 18 12:00:00 pfSense kernel: panic: page fault", "critical"},
+		{"Oct 18 12:00:00 pfSense php-fpm[1]: PHP ERROR: Type: 1", "error"},
+		{"Oct 18 12:00:00 pfSense check_reload_status: Warning: reloading", "warning"},
+		{"Oct 18 12:00:00 pfSense syslogd: restart", "info"},
+	}
+
+	for _, tt := range tests {
+		if severity := systemLogSeverity(tt.line); severity != tt.severity {
+			t.Errorf("Expected severity '%s' for '%s', got '%s'", tt.severity, tt.line, severity)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package collectors
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pfrest/pfsense_exporter/internal/log"
+	"github.com/pfrest/pfsense_exporter/internal/registry"
+	"github.com/pfrest/pfsense_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// userAdminPrivilege is the pfSense privilege that grants access to all pages of the webConfigurator.
+const userAdminPrivilege = "page-all"
+
+// userAdminGroup is the built-in pfSense group whose members are granted admin privileges.
+const userAdminGroup = "admins"
+
+// userExpiresLayout is the layout pfSense uses to store a user's account expiration date.
+const userExpiresLayout = "01/02/2006"
+
+// init ensures the collector is automatically added to the registry.
+func init() {
+	registry.Register(NewUsersCollector())
+}
+
+// UsersCollector collects metrics about local user accounts and REST API keys.
+type UsersCollector struct {
+	usersCount                *prometheus.GaugeVec
+	usersDisabledCount        *prometheus.GaugeVec
+	usersAdminCount           *prometheus.GaugeVec
+	userInfo                  *prometheus.GaugeVec
+	userExpirationTimestamp   *prometheus.GaugeVec
+	usersAPIKeysCount         *prometheus.GaugeVec
+	usersAPIKeysOrphanedCount *prometheus.GaugeVec
+}
+
+// UserStats represents the structure of the local user data returned by the API.
+type UserStats struct {
+	Name     string   `json:"name"`
+	UID      int64    `json:"uid"`
+	Descr    string   `json:"descr"`
+	Disabled bool     `json:"disabled"`
+	Expires  string   `json:"expires"`
+	Priv     []string `json:"priv"`
+}
+
+// UserGroupStats represents the structure of the user group data returned by the API. Group members are referenced by
+// their user's UID.
+type UserGroupStats struct {
+	Name   string   `json:"name"`
+	Member []string `json:"member"`
+	Priv   []string `json:"priv"`
+}
+
+// APIKeyStats represents the structure of the REST API key data returned by the API.
+type APIKeyStats struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Descr    string `json:"descr"`
+}
+
+// NewUsersCollector is the constructor
+func NewUsersCollector() *UsersCollector {
+	return &UsersCollector{
+		usersCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "users_count",
+				Help: "The number of local user accounts.",
+			},
+			[]string{"host"},
+		),
+		usersDisabledCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "users_disabled_count",
+				Help: "The number of disabled local user accounts.",
+			},
+			[]string{"host"},
+		),
+		usersAdminCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "users_admin_count",
+				Help: "The number of local user accounts with admin privileges.",
+			},
+			[]string{"host"},
+		),
+		userInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "user_info",
+				Help: "Contains details about the local user account's description, status and privileges.",
+			},
+			[]string{"host", "username", "descr", "disabled", "admin"},
+		),
+		userExpirationTimestamp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "user_expiration_timestamp_seconds",
+				Help: "The Unix timestamp the local user account expires at.",
+			},
+			[]string{"host", "username"},
+		),
+		usersAPIKeysCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "users_api_keys_count",
+				Help: "The number of REST API keys belonging to the user.",
+			},
+			[]string{"host", "username"},
+		),
+		usersAPIKeysOrphanedCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: registry.MetricsPrefix + "users_api_keys_orphaned_count",
+				Help: "The number of REST API keys belonging to a user that no longer exists or is disabled.",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// Name returns the name of the collector.
+func (c *UsersCollector) Name() string {
+	return "users"
+}
+
+// Describe sends the metric descriptions to the channel.
+func (c *UsersCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.usersCount.Describe(ch)
+	c.usersDisabledCount.Describe(ch)
+	c.usersAdminCount.Describe(ch)
+	c.userInfo.Describe(ch)
+	c.userExpirationTimestamp.Describe(ch)
+	c.usersAPIKeysCount.Describe(ch)
+	c.usersAPIKeysOrphanedCount.Describe(ch)
+}
+
+// Collect fetches the stats and sends them to the channel.
+func (c *UsersCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	// Collect the local users from the target
+	var users []UserStats
+	if err := utils.RequestData(target, "GET", "/api/v2/users", &users); err != nil {
+		log.Error("users", "failed to fetch users from host %s: %s", target.Host, err.Error())
+		return
+	}
+
+	// Collect the user groups from the target to determine which users are admins through group membership
+	var groups []UserGroupStats
+	if err := utils.RequestData(target, "GET", "/api/v2/user/groups", &groups); err != nil {
+		log.Error("users", "failed to fetch user groups from host %s: %s", target.Host, err.Error())
+	}
+
+	// Reset metrics before collecting new data
+	c.resetMetrics()
+
+	// Extract metrics for each user identified
+	disabled, admins := 0, 0
+	enabled := make(map[string]bool, len(users))
+	for _, user := range users {
+		isAdmin := userIsAdmin(user, groups)
+		if user.Disabled {
+			disabled++
+		}
+		if isAdmin {
+			admins++
+		}
+		enabled[user.Name] = !user.Disabled
+
+		c.userInfo.WithLabelValues(target.Host, user.Name, user.Descr, strconv.FormatBool(user.Disabled), strconv.FormatBool(isAdmin)).Set(1)
+
+		// Only report the expiration for accounts that have one set
+		if user.Expires != "" {
+			expires, err := time.Parse(userExpiresLayout, user.Expires)
+			if err != nil {
+				log.Debug("users", "failed to parse expiration '%s' of user %s on host %s: %s", user.Expires, user.Name, target.Host, err.Error())
+			} else {
+				c.userExpirationTimestamp.WithLabelValues(target.Host, user.Name).Set(float64(expires.Unix()))
+			}
+		}
+	}
+	c.usersCount.WithLabelValues(target.Host).Set(float64(len(users)))
+	c.usersDisabledCount.WithLabelValues(target.Host).Set(float64(disabled))
+	c.usersAdminCount.WithLabelValues(target.Host).Set(float64(admins))
+
+	// Extract metrics for each REST API key identified
+	var keys []APIKeyStats
+	if err := utils.RequestData(target, "GET", "/api/v2/auth/keys", &keys); err != nil {
+		log.Error("users", "failed to fetch REST API keys from host %s: %s", target.Host, err.Error())
+	} else {
+		orphaned := 0
+		for _, key := range keys {
+			c.usersAPIKeysCount.WithLabelValues(target.Host, key.Username).Inc()
+			if !enabled[key.Username] {
+				orphaned++
+			}
+		}
+		c.usersAPIKeysOrphanedCount.WithLabelValues(target.Host).Set(float64(orphaned))
+	}
+
+	// Collect the metrics
+	c.usersCount.Collect(ch)
+	c.usersDisabledCount.Collect(ch)
+	c.usersAdminCount.Collect(ch)
+	c.userInfo.Collect(ch)
+	c.userExpirationTimestamp.Collect(ch)
+	c.usersAPIKeysCount.Collect(ch)
+	c.usersAPIKeysOrphanedCount.Collect(ch)
+}
+
+// resetMetrics resets all metrics in the collector.
+func (c *UsersCollector) resetMetrics() {
+	c.usersCount.Reset()
+	c.usersDisabledCount.Reset()
+	c.usersAdminCount.Reset()
+	c.userInfo.Reset()
+	c.userExpirationTimestamp.Reset()
+	c.usersAPIKeysCount.Reset()
+	c.usersAPIKeysOrphanedCount.Reset()
+}
+
+// userIsAdmin checks whether a user has admin privileges, either directly or through a group with admin privileges.
+func userIsAdmin(user UserStats, groups []UserGroupStats) bool {
+	if slices.Contains(user.Priv, userAdminPrivilege) {
+		return true
+	}
+	uid := strconv.FormatInt(user.UID, 10)
+	for _, group := range groups {
+		if !slices.Contains(group.Member, uid) {
+			continue
+		}
+		if group.Name == userAdminGroup || slices.Contains(group.Priv, userAdminPrivilege) {
+			return true
+		}
+	}
+	return false
+}
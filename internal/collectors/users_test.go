@@ -0,0 +1,84 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsersCollectorCollectWithTarget(t *testing.T) {
+	target := newTestTarget(t, map[string]string{
+		"/api/v2/users": `[
+			{"name":"admin","uid":0,"descr":"System Administrator","disabled":false,"expires":"","priv":[]},
+			{"name":"jdoe","uid":2000,"descr":"Contractor","disabled":false,"expires":"01/31/2025","priv":["page-all"]},
+			{"name":"old","uid":2001,"descr":"","disabled":true,"expires":"","priv":[]},
+			{"name":"viewer","uid":2002,"descr":"","disabled":false,"expires":"not a date","priv":["page-dashboard-all"]}
+		]`,
+		"/api/v2/user/groups": `[{"name":"admins","member":["0"],"priv":["page-all"]}]`,
+		"/api/v2/auth/keys": `[
+			{"id":0,"username":"admin","descr":"automation"},
+			{"id":1,"username":"old","descr":"legacy"},
+			{"id":2,"username":"removed","descr":"gone"}
+		]`,
+	})
+
+	samples := collectTestSamples(t, NewUsersCollector(), target)
+
+	// Verify the user counts
+	if sample, ok := findTestSample(samples, "pfsense_users_count", nil); !ok || sample.value != 4 {
+		t.Errorf("Expected 4 users, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_users_disabled_count", nil); !ok || sample.value != 1 {
+		t.Errorf("Expected 1 disabled user, got %v", sample.value)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_users_admin_count", nil); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 admin users, got %v", sample.value)
+	}
+	if _, ok := findTestSample(samples, "pfsense_user_info", map[string]string{"username": "admin", "admin": "true"}); !ok {
+		t.Error("Expected admin to be reported as an admin through group membership")
+	}
+
+	// Verify only valid expirations are reported
+	expires := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	if sample, ok := findTestSample(samples, "pfsense_user_expiration_timestamp_seconds", map[string]string{"username": "jdoe"}); !ok || sample.value != float64(expires.Unix()) {
+		t.Errorf("Expected jdoe to expire at %d, got %v", expires.Unix(), sample.value)
+	}
+	if count := countTestSamples(samples, "pfsense_user_expiration_timestamp_seconds"); count != 1 {
+		t.Errorf("Expected 1 expiration metric, got %d", count)
+	}
+
+	// Verify the API key metrics
+	if count := countTestSamples(samples, "pfsense_users_api_keys_count"); count != 3 {
+		t.Errorf("Expected 3 API key counts, got %d", count)
+	}
+	if sample, ok := findTestSample(samples, "pfsense_users_api_keys_orphaned_count", nil); !ok || sample.value != 2 {
+		t.Errorf("Expected 2 orphaned API keys, got %v", sample.value)
+	}
+}
+
+func TestUserIsAdmin(t *testing.T) {
+	groups := []UserGroupStats{
+		{Name: "admins", Member: []string{"0"}},
+		{Name: "netops", Member: []string{"2000"}, Priv: []string{"page-all"}},
+		{Name: "viewers", Member: []string{"2001"}, Priv: []string{"page-dashboard-all"}},
+	}
+
+	tests := []struct {
+		user     UserStats
+		expected bool
+	}{
+		{UserStats{Name: "admin", UID: 0}, true},
+		{UserStats{Name: "ops", UID: 2000}, true},
+		{UserStats{Name: "direct", UID: 2003, Priv: []string{"page-all"}}, true},
+		{UserStats{Name: "viewer", UID: 2001}, false},
+		{UserStats{Name: "nobody", UID: 2004}, false},
+		{UserStats{Name: "2000", UID: 2005}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user.Name, func(t *testing.T) {
+			if result := userIsAdmin(tt.user, groups); result != tt.expected {
+				t.Errorf("Expected %t for user '%s', got %t", tt.expected, tt.user.Name, result)
+			}
+		})
+	}
+}
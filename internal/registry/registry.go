@@ -68,14 +68,20 @@ func (mc *MasterCollector) Collect(ch chan<- prometheus.Metric) {
 			// Create a buffered channel for this collector's metrics
 			collectorCh := make(chan prometheus.Metric, mc.Target.MaxCollectorBufferSize)
 
+			// Forward metrics to the main channel as they are collected so collectors producing
+			// more metrics than the buffer size don't block
+			forwarded := make(chan struct{})
+			go func() {
+				defer close(forwarded)
+				for metric := range collectorCh {
+					ch <- metric
+				}
+			}()
+
 			// Collect metrics from this collector
 			collector.CollectWithTarget(collectorCh, mc.Target)
 			close(collectorCh)
-
-			// Forward all metrics to the main channel
-			for metric := range collectorCh {
-				ch <- metric
-			}
+			<-forwarded
 		}(c)
 	}
 
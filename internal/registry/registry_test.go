@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/pfrest/pfsense_exporter/internal/log"
@@ -27,6 +28,26 @@ func (m *MockCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *u
 	// Don't close the channel here - let the caller handle it
 }
 
+// FloodCollector implements TargetedCollector and emits a fixed number of metrics for testing
+type FloodCollector struct {
+	count int
+}
+
+func (f *FloodCollector) Name() string {
+	return "flood"
+}
+
+func (f *FloodCollector) Describe(ch chan<- *prometheus.Desc) {
+	// Mock implementation
+}
+
+func (f *FloodCollector) CollectWithTarget(ch chan<- prometheus.Metric, target *utils.Target) {
+	desc := prometheus.NewDesc("flood_metric", "A test metric.", []string{"index"}, nil)
+	for i := 0; i < f.count; i++ {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(i), strconv.Itoa(i))
+	}
+}
+
 func TestRegister(t *testing.T) {
 	// Save original collectors and restore after test
 	originalCollectors := collectors
@@ -354,3 +375,34 @@ func TestMasterCollectorCollectWithSkippedCollectors(t *testing.T) {
 		t.Error("Expected collector3 to be called")
 	}
 }
+
+func TestMasterCollectorCollectExceedsBufferSize(t *testing.T) {
+	// Save original collectors and restore after test
+	originalCollectors := collectors
+	defer func() { collectors = originalCollectors }()
+
+	// Set up a collector that produces more metrics than the buffer can hold
+	collectors = []TargetedCollector{&FloodCollector{count: 50}}
+
+	target := &utils.Target{
+		Host:                    "test.com",
+		MaxCollectorConcurrency: 1,
+		MaxCollectorBufferSize:  10,
+	}
+	mc := NewMasterCollector(target)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		mc.Collect(ch)
+		close(ch)
+	}()
+
+	// All metrics should be forwarded without blocking the collector
+	count := 0
+	for range ch {
+		count++
+	}
+	if count != 50 {
+		t.Errorf("Expected 50 metrics, got %d", count)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
+	"slices"
 
 	"github.com/pfrest/pfsense_exporter/internal/log"
 	"gopkg.in/yaml.v3"
@@ -13,9 +15,16 @@ var Cfg *Config
 
 // Config is the top-level structure for the YAML config file.
 type Config struct {
-	Address string   `yaml:"address"` // Address is the address the exporter will bind to.
-	Port    int      `yaml:"port"`    // Port is the port the exporter will listen on.
-	Targets []Target `yaml:"targets"` // Targets contains the configuration for the targets to scrape.
+	Address string   `yaml:"address"`  // Address is the address the exporter will bind to.
+	Port    int      `yaml:"port"`     // Port is the port the exporter will listen on.
+	Targets []Target `yaml:"targets"`  // Targets contains the configuration for the targets to scrape.
+	HAPairs []HAPair `yaml:"ha_pairs"` // HAPairs contains the configuration for CARP high availability clusters.
+}
+
+// HAPair represents a single CARP high availability cluster in the YAML.
+type HAPair struct {
+	Name    string   `yaml:"name"`    // Name is the name used to reference the cluster.
+	Targets []string `yaml:"targets"` // Targets contains the hosts of the targets that are members of the cluster.
 }
 
 // Target represents a single target object in the YAML.
@@ -32,6 +41,60 @@ type Target struct {
 	Collectors              []string `yaml:"collectors"`                // Collectors is the list of collectors to use for the target.
 	MaxCollectorConcurrency int      `yaml:"max_collector_concurrency"` // MaxCollectorConcurrency is the maximum number of collectors allowed to run concurrently.
 	MaxCollectorBufferSize  int      `yaml:"max_collector_buffer_size"` // MaxCollectorBufferSize is the maximum size of the collector's metric buffer.
+
+	FirewallStatesDetail FirewallStatesDetailOptions `yaml:"firewall_states_detail"` // FirewallStatesDetail configures the firewall_states_detail collector.
+	NAT                  NATOptions                  `yaml:"nat"`                    // NAT configures the nat collector.
+	Neighbors            NeighborsOptions            `yaml:"neighbors"`              // Neighbors configures the neighbors collector.
+	PFBlockerNG          PFBlockerNGOptions          `yaml:"pfblockerng"`            // PFBlockerNG configures the pfblockerng collector.
+	SystemLog            SystemLogOptions            `yaml:"system_log"`             // SystemLog configures the system_log collector.
+}
+
+// FirewallStatesDetailOptions represents the firewall_states_detail collector options of a target in the YAML.
+type FirewallStatesDetailOptions struct {
+	TopN      int `yaml:"top_n"`      // TopN is the number of top source and destination addresses to report.
+	PageSize  int `yaml:"page_size"`  // PageSize is the number of states to request from the API at a time.
+	MaxStates int `yaml:"max_states"` // MaxStates is the maximum number of states to scan per scrape.
+}
+
+// NATOptions represents the nat collector options of a target in the YAML.
+type NATOptions struct {
+	StateCounts bool `yaml:"state_counts"` // StateCounts enables counting the firewall states of each port forward.
+	PageSize    int  `yaml:"page_size"`    // PageSize is the number of states to request from the API at a time.
+	MaxStates   int  `yaml:"max_states"`   // MaxStates is the maximum number of states to scan per scrape.
+}
+
+// NeighborsOptions represents the neighbors collector options of a target in the YAML.
+type NeighborsOptions struct {
+	IncludeEntries bool `yaml:"include_entries"` // IncludeEntries enables the per-entry info metric.
+	MaxEntries     int  `yaml:"max_entries"`     // MaxEntries is the maximum number of per-entry info metrics to report.
+}
+
+// PFBlockerNGOptions represents the pfblockerng collector options of a target in the YAML.
+type PFBlockerNGOptions struct {
+	MaxAliases int `yaml:"max_aliases"` // MaxAliases is the maximum number of aliases whose pf tables are fetched per scrape.
+}
+
+// SystemLogOptions represents the system_log collector options of a target in the YAML.
+type SystemLogOptions struct {
+	Logs    []string   `yaml:"logs"`    // Logs is the list of logs to read (e.g. system, gateways or dhcp).
+	Classes []LogClass `yaml:"classes"` // Classes is the list of regex classes to count log lines by.
+}
+
+// LogClass represents a class of log lines matching a regular expression in the YAML.
+type LogClass struct {
+	Name    string         `yaml:"name"`    // Name is the value of the class label for matching lines.
+	Pattern string         `yaml:"pattern"` // Pattern is the regular expression lines must match.
+	Regexp  *regexp.Regexp `yaml:"-"`       // Regexp is the compiled Pattern, set during validation.
+}
+
+// defaultSystemLogLogs are the logs read by the system_log collector if none are configured.
+var defaultSystemLogLogs = []string{"system", "gateways", "dhcp"}
+
+// defaultSystemLogClasses are the classes used by the system_log collector if none are configured.
+var defaultSystemLogClasses = []LogClass{
+	{Name: "mbuf_exhausted", Pattern: `(?i)mbuf clusters exhausted|nmbclusters limit reached`},
+	{Name: "php_error", Pattern: `(?i)\bPHP (fatal |parse )?(error|warning)`},
+	{Name: "arp_moved", Pattern: `arp: .+ moved from`},
 }
 
 // Validate validates the fields of a given Target.
@@ -57,6 +120,21 @@ func (t *Target) Validate() (*Target, error) {
 	if err := t.ValidateMaxCollectorBufferSize(); err != nil {
 		return nil, err
 	}
+	if err := t.validateFirewallStatesDetail(); err != nil {
+		return nil, err
+	}
+	if err := t.validateNAT(); err != nil {
+		return nil, err
+	}
+	if err := t.validateNeighbors(); err != nil {
+		return nil, err
+	}
+	if err := t.validatePFBlockerNG(); err != nil {
+		return nil, err
+	}
+	if err := t.validateSystemLog(); err != nil {
+		return nil, err
+	}
 
 	return t, nil
 }
@@ -146,6 +224,34 @@ func (c *Config) ValidateTargets() error {
 	return nil
 }
 
+// ValidateHAPairs checks each HAPair in a Config for correctness.
+func (c *Config) ValidateHAPairs() error {
+	names := make(map[string]bool, len(c.HAPairs))
+	for idx, pair := range c.HAPairs {
+		// Ensure the cluster has a unique name
+		if pair.Name == "" {
+			return fmt.Errorf("HA pair %d 'name' is a required field", idx)
+		}
+		if names[pair.Name] {
+			return fmt.Errorf("HA pair 'name' must be unique, found duplicate '%s'", pair.Name)
+		}
+		names[pair.Name] = true
+
+		// Ensure the cluster has at least two members
+		if len(pair.Targets) < 2 {
+			return fmt.Errorf("HA pair '%s' must have at least 2 targets", pair.Name)
+		}
+
+		// Ensure each member is a configured target
+		for _, host := range pair.Targets {
+			if _, err := c.getTarget(host); err != nil {
+				return fmt.Errorf("HA pair '%s' references a target that is not configured: %s", pair.Name, host)
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateAddress checks that the global 'address' field is set and is a valid IP
 func (c *Config) ValidateAddress() error {
 	// Default to localhost if not set
@@ -209,6 +315,114 @@ func (t *Target) ValidateMaxCollectorBufferSize() error {
 	return nil
 }
 
+// validateFirewallStatesDetail checks the firewall_states_detail collector options are valid.
+func (t *Target) validateFirewallStatesDetail() error {
+	// Default to the top 10 addresses if not set
+	if t.FirewallStatesDetail.TopN == 0 {
+		t.FirewallStatesDetail.TopN = 10
+	}
+	if t.FirewallStatesDetail.TopN < 1 || t.FirewallStatesDetail.TopN > 100 {
+		return fmt.Errorf("Target 'firewall_states_detail.top_n' must be between 1 and 100 for host '%s'", t.Host)
+	}
+
+	// Default to pages of 1000 states if not set
+	if t.FirewallStatesDetail.PageSize == 0 {
+		t.FirewallStatesDetail.PageSize = 1000
+	}
+	if t.FirewallStatesDetail.PageSize < 100 || t.FirewallStatesDetail.PageSize > 10000 {
+		return fmt.Errorf("Target 'firewall_states_detail.page_size' must be between 100 and 10000 for host '%s'", t.Host)
+	}
+
+	// Default to scanning at most 100000 states if not set
+	if t.FirewallStatesDetail.MaxStates == 0 {
+		t.FirewallStatesDetail.MaxStates = 100000
+	}
+	if t.FirewallStatesDetail.MaxStates < 1000 || t.FirewallStatesDetail.MaxStates > 1000000 {
+		return fmt.Errorf("Target 'firewall_states_detail.max_states' must be between 1000 and 1000000 for host '%s'", t.Host)
+	}
+	return nil
+}
+
+// validateNAT checks the nat collector options are valid.
+func (t *Target) validateNAT() error {
+	// Default to pages of 1000 states if not set
+	if t.NAT.PageSize == 0 {
+		t.NAT.PageSize = 1000
+	}
+	if t.NAT.PageSize < 100 || t.NAT.PageSize > 10000 {
+		return fmt.Errorf("Target 'nat.page_size' must be between 100 and 10000 for host '%s'", t.Host)
+	}
+
+	// Default to scanning at most 100000 states if not set
+	if t.NAT.MaxStates == 0 {
+		t.NAT.MaxStates = 100000
+	}
+	if t.NAT.MaxStates < 1000 || t.NAT.MaxStates > 1000000 {
+		return fmt.Errorf("Target 'nat.max_states' must be between 1000 and 1000000 for host '%s'", t.Host)
+	}
+	return nil
+}
+
+// validateNeighbors checks the neighbors collector options are valid.
+func (t *Target) validateNeighbors() error {
+	// Default to a maximum of 1000 entries if not set
+	if t.Neighbors.MaxEntries == 0 {
+		t.Neighbors.MaxEntries = 1000
+	}
+	if t.Neighbors.MaxEntries < 1 || t.Neighbors.MaxEntries > 10000 {
+		return fmt.Errorf("Target 'neighbors.max_entries' must be between 1 and 10000 for host '%s'", t.Host)
+	}
+	return nil
+}
+
+// validatePFBlockerNG checks the pfblockerng collector options are valid.
+func (t *Target) validatePFBlockerNG() error {
+	// Default to a maximum of 50 aliases if not set
+	if t.PFBlockerNG.MaxAliases == 0 {
+		t.PFBlockerNG.MaxAliases = 50
+	}
+	if t.PFBlockerNG.MaxAliases < 1 || t.PFBlockerNG.MaxAliases > 1000 {
+		return fmt.Errorf("Target 'pfblockerng.max_aliases' must be between 1 and 1000 for host '%s'", t.Host)
+	}
+	return nil
+}
+
+// validateSystemLog checks the system_log collector options are valid and compiles the class patterns.
+func (t *Target) validateSystemLog() error {
+	// Default to the system, gateways and DHCP logs if not set
+	if len(t.SystemLog.Logs) == 0 {
+		t.SystemLog.Logs = slices.Clone(defaultSystemLogLogs)
+	}
+	for _, name := range t.SystemLog.Logs {
+		if name == "" {
+			return fmt.Errorf("Target 'system_log.logs' cannot contain empty log names for host '%s'", t.Host)
+		}
+	}
+
+	// Default to the built-in classes if not set
+	if len(t.SystemLog.Classes) == 0 {
+		t.SystemLog.Classes = slices.Clone(defaultSystemLogClasses)
+	}
+	names := make(map[string]bool, len(t.SystemLog.Classes))
+	for idx := range t.SystemLog.Classes {
+		class := &t.SystemLog.Classes[idx]
+		if class.Name == "" || class.Pattern == "" {
+			return fmt.Errorf("Target 'system_log.classes' must each have a 'name' and 'pattern' for host '%s'", t.Host)
+		}
+		if names[class.Name] {
+			return fmt.Errorf("Target 'system_log.classes' contains duplicate class '%s' for host '%s'", class.Name, t.Host)
+		}
+		names[class.Name] = true
+
+		re, err := regexp.Compile(class.Pattern)
+		if err != nil {
+			return fmt.Errorf("Target 'system_log.classes' pattern for class '%s' is invalid for host '%s': %w", class.Name, t.Host, err)
+		}
+		class.Regexp = re
+	}
+	return nil
+}
+
 // Validate checks the entire Config for correctness.
 func (c *Config) Validate() error {
 	if err := c.ValidateAddress(); err != nil {
@@ -220,6 +434,9 @@ func (c *Config) Validate() error {
 	if err := c.ValidateTargets(); err != nil {
 		return fmt.Errorf("target validation failed: %w", err)
 	}
+	if err := c.ValidateHAPairs(); err != nil {
+		return fmt.Errorf("HA pair validation failed: %w", err)
+	}
 	return nil
 }
 
@@ -242,7 +459,22 @@ func LoadConfig(path string) {
 
 // GetTarget obtains the Target configuration for a specific target host.
 func GetTarget(host string) (*Target, error) {
-	for _, target := range Cfg.Targets {
+	return Cfg.getTarget(host)
+}
+
+// GetHAPair obtains the HAPair configuration for a specific cluster name.
+func GetHAPair(name string) (*HAPair, error) {
+	for _, pair := range Cfg.HAPairs {
+		if pair.Name == name {
+			return &pair, nil
+		}
+	}
+	return nil, fmt.Errorf("HA pair not configured: %s", name)
+}
+
+// getTarget obtains the Target configuration for a specific target host from the Config.
+func (c *Config) getTarget(host string) (*Target, error) {
+	for _, target := range c.Targets {
 		if target.Host == host {
 			return &target, nil
 		}
@@ -221,6 +221,161 @@ func TestTargetValidateMaxCollectorBufferSize(t *testing.T) {
 	}
 }
 
+func TestTargetValidateFirewallStatesDetail(t *testing.T) {
+	// Test default values
+	target := &Target{Host: "test.com", Port: 443}
+	if err := target.validateFirewallStatesDetail(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if target.FirewallStatesDetail.TopN != 10 {
+		t.Errorf("Expected default top_n 10, got %d", target.FirewallStatesDetail.TopN)
+	}
+	if target.FirewallStatesDetail.PageSize != 1000 {
+		t.Errorf("Expected default page_size 1000, got %d", target.FirewallStatesDetail.PageSize)
+	}
+	if target.FirewallStatesDetail.MaxStates != 100000 {
+		t.Errorf("Expected default max_states 100000, got %d", target.FirewallStatesDetail.MaxStates)
+	}
+
+	// Test top_n out of range
+	target = &Target{Host: "test.com", Port: 443, FirewallStatesDetail: FirewallStatesDetailOptions{TopN: 101}}
+	if err := target.validateFirewallStatesDetail(); err == nil {
+		t.Error("Expected error for top_n > 100")
+	}
+
+	// Test page_size out of range
+	target = &Target{Host: "test.com", Port: 443, FirewallStatesDetail: FirewallStatesDetailOptions{PageSize: 50}}
+	if err := target.validateFirewallStatesDetail(); err == nil {
+		t.Error("Expected error for page_size < 100")
+	}
+
+	// Test max_states out of range
+	target = &Target{Host: "test.com", Port: 443, FirewallStatesDetail: FirewallStatesDetailOptions{MaxStates: 500}}
+	if err := target.validateFirewallStatesDetail(); err == nil {
+		t.Error("Expected error for max_states < 1000")
+	}
+}
+
+func TestTargetValidateNAT(t *testing.T) {
+	// Test default values
+	target := &Target{Host: "test.com", Port: 443}
+	if err := target.validateNAT(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if target.NAT.StateCounts {
+		t.Error("Expected state_counts to be disabled by default")
+	}
+	if target.NAT.PageSize != 1000 {
+		t.Errorf("Expected default page_size 1000, got %d", target.NAT.PageSize)
+	}
+	if target.NAT.MaxStates != 100000 {
+		t.Errorf("Expected default max_states 100000, got %d", target.NAT.MaxStates)
+	}
+
+	// Test page_size out of range
+	target = &Target{Host: "test.com", Port: 443, NAT: NATOptions{PageSize: 20000}}
+	if err := target.validateNAT(); err == nil {
+		t.Error("Expected error for page_size > 10000")
+	}
+
+	// Test max_states out of range
+	target = &Target{Host: "test.com", Port: 443, NAT: NATOptions{MaxStates: 2000000}}
+	if err := target.validateNAT(); err == nil {
+		t.Error("Expected error for max_states > 1000000")
+	}
+}
+
+func TestTargetValidateNeighbors(t *testing.T) {
+	// Test default value
+	target := &Target{Host: "test.com", Port: 443}
+	if err := target.validateNeighbors(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if target.Neighbors.MaxEntries != 1000 {
+		t.Errorf("Expected default max_entries 1000, got %d", target.Neighbors.MaxEntries)
+	}
+
+	// Test max_entries out of range
+	target = &Target{Host: "test.com", Port: 443, Neighbors: NeighborsOptions{MaxEntries: 10001}}
+	if err := target.validateNeighbors(); err == nil {
+		t.Error("Expected error for max_entries > 10000")
+	}
+	target = &Target{Host: "test.com", Port: 443, Neighbors: NeighborsOptions{MaxEntries: -1}}
+	if err := target.validateNeighbors(); err == nil {
+		t.Error("Expected error for max_entries < 1")
+	}
+}
+
+func TestTargetValidatePFBlockerNG(t *testing.T) {
+	// Test default value
+	target := &Target{Host: "test.com", Port: 443}
+	if err := target.validatePFBlockerNG(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if target.PFBlockerNG.MaxAliases != 50 {
+		t.Errorf("Expected default max_aliases 50, got %d", target.PFBlockerNG.MaxAliases)
+	}
+
+	// Test max_aliases out of range
+	target = &Target{Host: "test.com", Port: 443, PFBlockerNG: PFBlockerNGOptions{MaxAliases: 1001}}
+	if err := target.validatePFBlockerNG(); err == nil {
+		t.Error("Expected error for max_aliases > 1000")
+	}
+	target = &Target{Host: "test.com", Port: 443, PFBlockerNG: PFBlockerNGOptions{MaxAliases: -1}}
+	if err := target.validatePFBlockerNG(); err == nil {
+		t.Error("Expected error for max_aliases < 1")
+	}
+}
+
+func TestTargetValidateSystemLog(t *testing.T) {
+	// Test default values
+	target := &Target{Host: "test.com", Port: 443}
+	if err := target.validateSystemLog(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(target.SystemLog.Logs) != 3 {
+		t.Errorf("Expected 3 default logs, got %v", target.SystemLog.Logs)
+	}
+	if len(target.SystemLog.Classes) != 3 {
+		t.Errorf("Expected 3 default classes, got %d", len(target.SystemLog.Classes))
+	}
+	for _, class := range target.SystemLog.Classes {
+		if class.Regexp == nil {
+			t.Errorf("Expected pattern of class '%s' to be compiled", class.Name)
+		}
+	}
+	if !target.SystemLog.Classes[0].Regexp.MatchString("[zone: mbuf_cluster] kern.ipc.nmbclusters limit reached") {
+		t.Error("Expected default mbuf_exhausted class to match nmbclusters messages")
+	}
+
+	// Test configured classes replace the defaults
+	target = &Target{Host: "test.com", Port: 443, SystemLog: SystemLogOptions{
+		Logs:    []string{"system"},
+		Classes: []LogClass{{Name: "ssh", Pattern: "sshd"}},
+	}}
+	if err := target.validateSystemLog(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(target.SystemLog.Classes) != 1 || !target.SystemLog.Classes[0].Regexp.MatchString("sshd[123]: Accepted") {
+		t.Errorf("Expected only the configured class, got %v", target.SystemLog.Classes)
+	}
+
+	// Test invalid options
+	invalid := []SystemLogOptions{
+		{Logs: []string{""}},
+		{Classes: []LogClass{{Name: "missing_pattern"}}},
+		{Classes: []LogClass{{Pattern: "missing_name"}}},
+		{Classes: []LogClass{{Name: "dup", Pattern: "a"}, {Name: "dup", Pattern: "b"}}},
+		{Classes: []LogClass{{Name: "invalid", Pattern: "("}}},
+	}
+	for _, options := range invalid {
+		target = &Target{Host: "test.com", Port: 443, SystemLog: options}
+		if err := target.validateSystemLog(); err == nil {
+			t.Errorf("Expected error for options %+v", options)
+		}
+	}
+}
+
 func TestTargetValidate(t *testing.T) {
 	// Test valid target
 	target := &Target{
@@ -499,6 +654,91 @@ func TestGetTarget(t *testing.T) {
 	}
 }
 
+func TestConfigValidateHAPairs(t *testing.T) {
+	targets := []Target{
+		{Host: "fw1.example.com", Port: 443},
+		{Host: "fw2.example.com", Port: 443},
+	}
+
+	tests := []struct {
+		name    string
+		pairs   []HAPair
+		wantErr string
+	}{
+		{
+			name:  "no_pairs",
+			pairs: nil,
+		},
+		{
+			name:  "valid_pair",
+			pairs: []HAPair{{Name: "edge", Targets: []string{"fw1.example.com", "fw2.example.com"}}},
+		},
+		{
+			name:    "missing_name",
+			pairs:   []HAPair{{Targets: []string{"fw1.example.com", "fw2.example.com"}}},
+			wantErr: "'name' is a required field",
+		},
+		{
+			name: "duplicate_name",
+			pairs: []HAPair{
+				{Name: "edge", Targets: []string{"fw1.example.com", "fw2.example.com"}},
+				{Name: "edge", Targets: []string{"fw1.example.com", "fw2.example.com"}},
+			},
+			wantErr: "must be unique",
+		},
+		{
+			name:    "too_few_targets",
+			pairs:   []HAPair{{Name: "edge", Targets: []string{"fw1.example.com"}}},
+			wantErr: "must have at least 2 targets",
+		},
+		{
+			name:    "unknown_target",
+			pairs:   []HAPair{{Name: "edge", Targets: []string{"fw1.example.com", "fw3.example.com"}}},
+			wantErr: "references a target that is not configured: fw3.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Targets: targets, HAPairs: tt.pairs}
+			err := config.ValidateHAPairs()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetHAPair(t *testing.T) {
+	// Save original Cfg and restore it after test
+	originalCfg := Cfg
+	defer func() { Cfg = originalCfg }()
+
+	Cfg = &Config{
+		HAPairs: []HAPair{
+			{Name: "edge", Targets: []string{"fw1.example.com", "fw2.example.com"}},
+		},
+	}
+
+	// Test existing pair
+	pair, err := GetHAPair("edge")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if pair == nil || len(pair.Targets) != 2 {
+		t.Errorf("Expected pair with 2 targets, got %v", pair)
+	}
+
+	// Test non-existing pair
+	_, err = GetHAPair("nonexistent")
+	if err == nil {
+		t.Error("Expected error for non-existing HA pair")
+	}
+}
+
 func TestTargetValidateAllErrorPaths(t *testing.T) {
 	tests := []struct {
 		name   string
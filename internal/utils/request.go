@@ -42,6 +42,119 @@ func Request(target *Target, method string, endpoint string) (*Response, error)
 	return executeAndParse(client, req)
 }
 
+// RequestData performs an HTTP request and unmarshals the response's data into v.
+func RequestData(target *Target, method string, endpoint string, v any) error {
+	resp, err := Request(target, method, endpoint)
+	if err != nil {
+		return err
+	}
+	if resp == nil || resp.Data == nil {
+		return fmt.Errorf("received nil response data")
+	}
+
+	// Unmarshal the response data into the provided value
+	if err := json.Unmarshal(resp.Data, v); err != nil {
+		return fmt.Errorf("error unmarshalling response data: %w", err)
+	}
+
+	return nil
+}
+
+// RequestStream performs an HTTP request and passes a decoder positioned at the response's data to fn. Unlike
+// RequestData, the response body is never read into memory in full, which allows large listings to be processed
+// one element at a time.
+func RequestStream(target *Target, method string, endpoint string, fn func(dec *json.Decoder) error) error {
+	client := newHTTPClient(target)
+	req, err := http.NewRequest(method, formatURL(target, endpoint), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	setHeaders(req, target)
+
+	// Send the request to the target
+	log.Debug("http", "sending %s request to %s", req.Method, req.URL)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Walk the top-level fields of the response, handing the data off to fn once it is reached
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return fmt.Errorf("error unmarshalling response body: %w", err)
+	}
+	var code int
+	var message string
+	var hasData bool
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling response body: %w", err)
+		}
+		switch key {
+		case "code":
+			err = dec.Decode(&code)
+		case "message":
+			err = dec.Decode(&message)
+		case "data":
+			// The code normally precedes the data, so avoid parsing the data of an unsuccessful response
+			if code != 0 && code != http.StatusOK {
+				return fmt.Errorf("received non-200 status code %d: %s", code, message)
+			}
+			hasData = true
+			if err := fn(dec); err != nil {
+				return fmt.Errorf("error unmarshalling response data: %w", err)
+			}
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return fmt.Errorf("error unmarshalling response body: %w", err)
+		}
+	}
+
+	// Ensure we received a successful response
+	if code != http.StatusOK {
+		return fmt.Errorf("received non-200 status code %d: %s", code, message)
+	}
+	if !hasData {
+		return fmt.Errorf("received nil response data")
+	}
+
+	return nil
+}
+
+// DecodeArray decodes a JSON array from dec one element at a time, passing each element to fn.
+func DecodeArray[T any](dec *json.Decoder, fn func(T) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token from dec and ensures it is the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected '%s', got '%v'", delim, token)
+	}
+	return nil
+}
+
 // newHTTPClient creates and configures an HTTP client based on the target's settings.
 func newHTTPClient(target *Target) *http.Client {
 	transport := &http.Transport{
@@ -520,3 +520,121 @@ func TestRequestInvalidMethod(t *testing.T) {
 		t.Errorf("Expected request creation error, got: %v", err)
 	}
 }
+
+func TestRequestData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/valid":
+			w.Write([]byte(`{"code": 200, "status": "ok", "data": [{"name": "test"}]}`))
+		case "/api/invalid":
+			w.Write([]byte(`{"code": 200, "status": "ok", "data": {"name": "test"}}`))
+		case "/api/null":
+			w.Write([]byte(`{"code": 200, "status": "ok"}`))
+		default:
+			w.Write([]byte(`{"code": 404, "status": "not found", "message": "endpoint not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	target := &Target{
+		Host:       serverURL.Hostname(),
+		Port:       port,
+		Scheme:     serverURL.Scheme,
+		AuthMethod: "basic",
+		Username:   "user",
+		Password:   "pass",
+		Timeout:    30,
+	}
+
+	// Test data is unmarshalled into the provided value
+	var items []struct {
+		Name string `json:"name"`
+	}
+	if err := RequestData(target, "GET", "/api/valid", &items); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "test" {
+		t.Errorf("Expected one item named 'test', got %v", items)
+	}
+
+	// Test data that doesn't match the provided value
+	if err := RequestData(target, "GET", "/api/invalid", &items); err == nil || !strings.Contains(err.Error(), "error unmarshalling response data") {
+		t.Errorf("Expected unmarshal error, got: %v", err)
+	}
+
+	// Test missing data
+	if err := RequestData(target, "GET", "/api/null", &items); err == nil || !strings.Contains(err.Error(), "received nil response data") {
+		t.Errorf("Expected nil data error, got: %v", err)
+	}
+
+	// Test non-200 responses
+	if err := RequestData(target, "GET", "/api/missing", &items); err == nil || !strings.Contains(err.Error(), "non-200 status code 404") {
+		t.Errorf("Expected non-200 error, got: %v", err)
+	}
+}
+
+func TestRequestStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/valid":
+			w.Write([]byte(`{"code": 200, "status": "ok", "response_id": "x", "data": [{"name": "a"}, {"name": "b"}], "_links": {}}`))
+		case "/api/invalid":
+			w.Write([]byte(`{"code": 200, "status": "ok", "data": {"name": "a"}}`))
+		case "/api/null":
+			w.Write([]byte(`{"code": 200, "status": "ok"}`))
+		default:
+			w.Write([]byte(`{"code": 404, "status": "not found", "message": "endpoint not found", "data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	target := &Target{
+		Host:       serverURL.Hostname(),
+		Port:       port,
+		Scheme:     serverURL.Scheme,
+		AuthMethod: "basic",
+		Username:   "user",
+		Password:   "pass",
+		Timeout:    30,
+	}
+
+	type item struct {
+		Name string `json:"name"`
+	}
+	var names []string
+	decodeNames := func(dec *json.Decoder) error {
+		return DecodeArray(dec, func(i item) error {
+			names = append(names, i.Name)
+			return nil
+		})
+	}
+
+	// Test each array element is decoded in order
+	if err := RequestStream(target, "GET", "/api/valid", decodeNames); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("Expected names [a b], got %v", names)
+	}
+
+	// Test data that isn't an array
+	if err := RequestStream(target, "GET", "/api/invalid", decodeNames); err == nil || !strings.Contains(err.Error(), "error unmarshalling response data") {
+		t.Errorf("Expected unmarshal error, got: %v", err)
+	}
+
+	// Test missing data
+	if err := RequestStream(target, "GET", "/api/null", decodeNames); err == nil || !strings.Contains(err.Error(), "received nil response data") {
+		t.Errorf("Expected nil data error, got: %v", err)
+	}
+
+	// Test non-200 responses
+	if err := RequestStream(target, "GET", "/api/missing", decodeNames); err == nil || !strings.Contains(err.Error(), "non-200 status code 404") {
+		t.Errorf("Expected non-200 error, got: %v", err)
+	}
+}